@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+const mapUsage = `Usage: spaceshipShooter map lint [-bots N] [file ...]
+
+Reports the problems found on the given map files, without files the campaign
+levels are checked. The exit status is 1 when any problem is found.
+`
+
+// mapCommand will run the map subcommand with the given arguments, it returns
+// the exit status
+func mapCommand(args []string, out io.Writer) int {
+	if len(args) == 0 || args[0] != "lint" {
+		fmt.Fprint(out, mapUsage)
+		return 2
+	}
+	flags := flag.NewFlagSet("map lint", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprint(out, mapUsage)
+		flags.PrintDefaults()
+	}
+	bots := flags.Int("bots", -1, "bots expected on each map, a negative number doesn't check the spawn positions")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	status := 0
+	if flags.NArg() == 0 {
+		for _, level := range levels {
+			if lintReport(out, level.Name, level.Lint()) {
+				status = 1
+			}
+		}
+		return status
+	}
+	for _, path := range flags.Args() {
+		m, err := game.LoadMap(path)
+		if err != nil {
+			fmt.Fprintf(out, "%s: %v\n", path, err)
+			status = 1
+			continue
+		}
+		issues := m.Lint()
+		if *bots >= 0 {
+			issues = game.Level{Map: m, Bots: make([]game.BotSpawn, *bots)}.Lint()
+		}
+		if lintReport(out, path, issues) {
+			status = 1
+		}
+	}
+	return status
+}
+
+// lintReport will write the given issues found on the given map, returns true
+// if there is any issue
+func lintReport(out io.Writer, name string, issues []game.LintIssue) bool {
+	for _, issue := range issues {
+		fmt.Fprintf(out, "%s: %s\n", name, issue)
+	}
+	return len(issues) > 0
+}
+
+// runSubcommand will run the subcommand given on the command line, if any,
+// and exit with his status
+func runSubcommand() {
+	if len(os.Args) < 2 {
+		return
+	}
+	switch os.Args[1] {
+	case "map":
+		os.Exit(mapCommand(os.Args[2:], os.Stdout))
+	case "serve-ssh":
+		os.Exit(serveSSHCommand(os.Args[2:], os.Stdout))
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/sshserve"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/view"
+)
+
+const serveSSHUsage = `Usage: spaceshipShooter serve-ssh [-listen address] [-port N] [-authorized-keys file] [-password-file file] [-no-auth] [-host-key file] [-mode mode] [-difficulty difficulty]
+
+Serves the game over ssh, each ssh session joins the same match as a new
+player named after the ssh user. The players need one of the authorized keys
+or the password, unless -no-auth is given. Connect with: ssh -p 2222 name@localhost
+`
+
+// serveSSHCommand will run the serve-ssh subcommand with the given arguments,
+// it returns the exit status
+func serveSSHCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("serve-ssh", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprint(out, serveSSHUsage)
+		flags.PrintDefaults()
+	}
+	listen := flags.String("listen", "127.0.0.1", "address where the ssh server listens, 0.0.0.0 for every interface")
+	port := flags.Int("port", 2222, "port where the ssh server listens")
+	authorizedKeys := flags.String("authorized-keys", "", "file with the public keys allowed to join, as an authorized_keys file")
+	passwordFile := flags.String("password-file", "", "file with the password allowed to join on his first line")
+	noAuth := flags.Bool("no-auth", false, "allow anyone to join without authentication")
+	hostKey := flags.String("host-key", defaultHostKeyPath(), "file with the host key, a new one is generated when it doesn't exist")
+	mode := flags.String("mode", "deathmatch", "game mode: campaign, deathmatch, team-deathmatch or survival")
+	difficulty := flags.String("difficulty", "normal", "difficulty: easy, normal, hard or nightmare")
+	friendlyFire := flags.Bool("friendly-fire", false, "allow the lasers to damage the allies")
+	fragLimit := flags.Int("frag-limit", game.DefaultFragLimit, "kills needed for win a deathmatch round")
+	fogOfWar := flags.Bool("fog-of-war", false, "only show what the players have on their line of sight")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	gameMode, err := game.ParseGameMode(*mode)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 2
+	}
+	gameDifficulty, err := game.ParseDifficulty(*difficulty)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 2
+	}
+	auth := sshserve.Auth{NoAuth: *noAuth}
+	if *authorizedKeys != "" {
+		if auth.AuthorizedKeys, err = sshserve.LoadAuthorizedKeys(*authorizedKeys); err != nil {
+			fmt.Fprintf(out, "Can't load the authorized keys from %s: %v\n", *authorizedKeys, err)
+			return 1
+		}
+	}
+	if *passwordFile != "" {
+		if auth.Password, err = sshserve.LoadPassword(*passwordFile); err != nil {
+			fmt.Fprintf(out, "Can't load the password from %s: %v\n", *passwordFile, err)
+			return 1
+		}
+	}
+	signer, err := sshserve.LoadHostKey(*hostKey)
+	if err != nil {
+		fmt.Fprintf(out, "Can't load the host key from %s: %v\n", *hostKey, err)
+		return 1
+	}
+	settings := view.Settings{
+		Rules: game.Rules{
+			Mode:         gameMode,
+			FriendlyFire: *friendlyFire,
+			FragLimit:    *fragLimit,
+			FogOfWar:     *fogOfWar,
+		},
+		Difficulty: gameDifficulty,
+		Input:      game.DefaultInputPolicy,
+	}
+	server, err := sshserve.NewServer(signer, auth, settings, levels...)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	address := net.JoinHostPort(*listen, strconv.Itoa(*port))
+	fmt.Fprintf(out, "Serving the game over ssh on %s\n", address)
+	if err := server.ListenAndServe(address); err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	return 0
+}
+
+// defaultHostKeyPath returns the path of the ssh host key inside the user
+// config directory, or on the current directory if there is no config directory
+func defaultHostKeyPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "ssh_host_key"
+	}
+	return filepath.Join(dir, "spaceship-shooter", "ssh_host_key")
+}
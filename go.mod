@@ -5,7 +5,9 @@ go 1.13
 require (
 	github.com/gdamore/tcell v1.3.0
 	github.com/gofrs/uuid v3.2.0+incompatible
+	github.com/mattn/go-runewidth v0.0.8
 	github.com/rivo/tview v0.0.0-20200414130344-8e06c826b3a5
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.5.1
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
@@ -8,10 +8,21 @@ import (
 )
 
 // Action interface should be implemented for each of the possible actions a
+// ship can do, the actions are validated before being performed
 type Action interface {
+	// Validate returns the reason the action can't be performed, nil if it
+	// is valid
+	Validate(e *Engine) error
 	Perform(e *Engine)
 }
 
+// Discarder is implemented by the actions that need to clean up when they are
+// never performed, the engine calls Discard for the actions dropped or
+// rejected
+type Discarder interface {
+	Discard(e *Engine)
+}
+
 // Direction is used to represent Direction constants.
 type Direction int
 
@@ -24,6 +35,20 @@ const (
 	DirectionRight
 )
 
+const (
+	// slowTileDelay is the minimum time a ship needs to wait before leave a
+	// slow tile
+	slowTileDelay = 400 * time.Millisecond
+	// hazardDamage is the hit points taken by a hazard tile
+	hazardDamage = 1
+)
+
+// valid returns wether the direction is one of the four an entity can move or
+// shoot to
+func (d Direction) valid() bool {
+	return d == DirectionUp || d == DirectionDown || d == DirectionLeft || d == DirectionRight
+}
+
 // RandomDirection will get a random direction avoiding DirectionNone
 func RandomDirection() Direction {
 	rn := rand.Intn(5-1) + 1
@@ -31,110 +56,118 @@ func RandomDirection() Direction {
 }
 
 // MoveAction keep the information about the actions launched by the user, such
-// as arrow keys pressed, an action is a definition of a movement applied to an
-// entity on the map and each movement can have a specific direction
+// as arrow keys pressed, or by the bots, an action is a definition of a
+// movement applied to an entity on the map and each movement can have a
+// specific direction
 type MoveAction struct {
-	ActorID   uuid.UUID
+	EntityID  uuid.UUID
 	Direction Direction
 	CreatedAt time.Time
 }
 
+// Validate will check the direction, the entity exists, is alive and, for the
+// players, is not doing too many actions
+func (m *MoveAction) Validate(e *Engine) error {
+	if !m.Direction.valid() {
+		return ErrInvalidDirection
+	}
+	return e.validateEntity(m.EntityID)
+}
+
 // Perform will execute all the behaviour associated to the action given
 func (m *MoveAction) Perform(e *Engine) {
-	actor := e.Actors[m.ActorID]
-	switch m.Direction {
-	case DirectionUp:
-		actor.Position.Y--
-	case DirectionDown:
-		actor.Position.Y++
-	case DirectionRight:
-		actor.Position.X++
-	case DirectionLeft:
-		actor.Position.X--
+	entity, exists := e.Entity(m.EntityID)
+	if !exists || entity.Health.Eliminated {
+		return
 	}
-	// Check if we collide with a wall
-	if e.GameMap.IsWall(actor.Position) {
+	entity, moved := e.GameMap.Move(entity, m.Direction, m.CreatedAt)
+	if !moved {
 		return
 	}
-	e.Actors[m.ActorID] = actor
-}
-
-// BotMoveAction defines the concept of movement for the bots
-// TODO merge this with the MoveAction, the Actor can be an interface and share
-// a common mover interface
-type BotMoveAction struct {
-	BotID     uuid.UUID
-	Direction Direction
-	CreatedAt time.Time
+	e.storeEntity(entity)
+	e.recordMove(m.EntityID)
+	if e.GameMap.ElementAt(entity.Position) == MapElementHazard {
+		e.damageEntity(m.EntityID, uuid.Nil, hazardDamage)
+	}
 }
 
-// Perform will execute the behaviour linked to a bot move action
-func (m *BotMoveAction) Perform(e *Engine) {
-	val, exists := e.Bots.Load(m.BotID)
-	if !exists {
-		return
-	}
-	bot := val.(Bot)
-	switch m.Direction {
+// Move returns the given entity after moving it on the given direction at the
+// given time, it returns false when a wall or a slow tile stops the entity.
+// The clients predicting their own movements share it with the engine
+func (m Map) Move(entity Entity, d Direction, now time.Time) (Entity, bool) {
+	position := entity.Position
+	switch d {
 	case DirectionUp:
-		bot.Position.Y--
+		position.Y--
 	case DirectionDown:
-		bot.Position.Y++
+		position.Y++
 	case DirectionRight:
-		bot.Position.X++
+		position.X++
 	case DirectionLeft:
-		bot.Position.X--
+		position.X--
 	}
-	// Check if we collide with a wall
-	if e.GameMap.IsWall(bot.Position) {
-		return
+	// Check if any of the entity cells collide with a wall or if the tile is
+	// slowing us down
+	for _, cell := range entity.cellsAt(position) {
+		if !m.CanMove(cell, d) {
+			return entity, false
+		}
+	}
+	if m.isSlowedDown(entity.Position, entity.LastMove, now) {
+		return entity, false
 	}
-	e.Bots.Store(m.BotID, bot)
+	entity.Position = position
+	entity.LastMove = now
+	return entity, true
 }
 
-// LaserAction keep the information about all the lasers actioned by the player
-// or players, and his position and also how long
-type LaserAction struct {
-	LaserID   uuid.UUID
+// isSlowedDown will check if a ship placed on the given position is still
+// stuck on a slow tile since his last movement
+func (m Map) isSlowedDown(position Point, lastMove time.Time, now time.Time) bool {
+	return m.ElementAt(position) == MapElementSlow && now.Sub(lastMove) < slowTileDelay
+}
+
+// FireAction defines a ship shooting his weapon on a direction, the engine
+// creates the laser from the current position of the ship
+type FireAction struct {
+	ShooterID uuid.UUID
 	Direction Direction
 	CreatedAt time.Time
 }
 
-// Perform will execute the specific behaviour for a laser action, which is
-// move until it collide with something, once we collide the perform will
-// take the specific reactions
-func (l *LaserAction) Perform(e *Engine) {
-	go func(la *LaserAction, en *Engine) {
-		las, _ := en.Lasers.Load(la.LaserID)
-		laser := las.(Laser)
-		ticker := time.NewTicker(18 * time.Millisecond)
-		for {
-			select {
-			case <-ticker.C:
-				switch la.Direction {
-				case DirectionUp:
-					laser.Position.Y--
-				case DirectionDown:
-					laser.Position.Y++
-				case DirectionRight:
-					laser.Position.X++
-				case DirectionLeft:
-					laser.Position.X--
-				}
-				// Check collisions with wall and also for other actors
-				if e.GameMap.IsWall(laser.Position) {
-					e.Lasers.Delete(l.LaserID)
-					return
-				}
-				if e.checkLaserCollisions(laser.Position, laser.Origin) {
-					e.Lasers.Delete(l.LaserID)
-					e.updateScores()
-					return
-				}
-				// Update position to be printed
-				e.Lasers.Store(l.LaserID, laser)
-			default:
-			}
-		}
-	}(l, e)
+// Validate will check the direction, the shooter exists, is alive and his
+// weapon is ready, the players can't do too many actions either
+func (f *FireAction) Validate(e *Engine) error {
+	if !f.Direction.valid() {
+		return ErrInvalidDirection
+	}
+	shooter, exists := e.Entity(f.ShooterID)
+	if !exists {
+		return ErrUnknownEntity
+	}
+	if err := e.validateCooldown(f.ShooterID, shooter.Weapon); err != nil {
+		return err
+	}
+	return e.validateEntity(f.ShooterID)
+}
+
+// Perform will create a laser of the shooter weapon and will launch it from
+// the shooter position
+func (f *FireAction) Perform(e *Engine) {
+	shooter, exists := e.Entity(f.ShooterID)
+	if !exists || shooter.Health.Eliminated {
+		return
+	}
+	e.recordCooldown(f.ShooterID)
+	laser := Laser{
+		ID:        uuid.Must(uuid.NewV4()),
+		Position:  shooter.Position,
+		ShooterID: f.ShooterID,
+		Team:      shooter.Team,
+		Damage:    shooter.Weapon.Damage,
+		Speed:     shooter.Weapon.Speed,
+	}
+	e.Lasers.Store(laser.ID, laser)
+	e.recordShot(f.ShooterID)
+	go e.launchLaser(laser, f.Direction)
 }
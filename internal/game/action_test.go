@@ -22,23 +22,17 @@ var mapTest = [][]rune{
 
 func TestMoveActionPerform(t *testing.T) {
 	// Setup basics scenario
-	var err error
-	actors := make(map[uuid.UUID]game.Actor)
-	actor := game.Actor{
-		Name: "TestActor",
+	player := game.Entity{
+		ID:   uuid.Must(uuid.NewV4()),
+		Name: "TestPlayer",
 		// Initial postion is on the center of the map
 		Position: game.Point{
 			X: 0,
 			Y: 0,
 		},
 	}
-	actor.ID, err = uuid.NewV4()
-	assert.Nil(t, err)
-	actors[actor.ID] = actor
-	e := &game.Engine{
-		GameMap: mapTest,
-		Actors:  actors,
-	}
+	e := &game.Engine{GameMap: mapTest}
+	assert.Nil(t, game.SetPlayers(player)(e))
 
 	movements := []struct {
 		name     string
@@ -48,7 +42,7 @@ func TestMoveActionPerform(t *testing.T) {
 		{
 			name: "Should move up",
 			action: game.MoveAction{
-				ActorID:   actor.ID,
+				EntityID:  player.ID,
 				Direction: game.DirectionUp,
 				CreatedAt: time.Now(),
 			},
@@ -60,7 +54,7 @@ func TestMoveActionPerform(t *testing.T) {
 		{
 			name: "Should move left",
 			action: game.MoveAction{
-				ActorID:   actor.ID,
+				EntityID:  player.ID,
 				Direction: game.DirectionLeft,
 				CreatedAt: time.Now(),
 			},
@@ -72,7 +66,7 @@ func TestMoveActionPerform(t *testing.T) {
 		{
 			name: "Should move down",
 			action: game.MoveAction{
-				ActorID:   actor.ID,
+				EntityID:  player.ID,
 				Direction: game.DirectionDown,
 				CreatedAt: time.Now(),
 			},
@@ -84,7 +78,7 @@ func TestMoveActionPerform(t *testing.T) {
 		{
 			name: "Should move right",
 			action: game.MoveAction{
-				ActorID:   actor.ID,
+				EntityID:  player.ID,
 				Direction: game.DirectionRight,
 				CreatedAt: time.Now(),
 			},
@@ -96,7 +90,7 @@ func TestMoveActionPerform(t *testing.T) {
 		{
 			name: "Shouldn't be able to move down",
 			action: game.MoveAction{
-				ActorID:   actor.ID,
+				EntityID:  player.ID,
 				Direction: game.DirectionDown,
 				CreatedAt: time.Now(),
 			},
@@ -108,8 +102,48 @@ func TestMoveActionPerform(t *testing.T) {
 	}
 	for _, tt := range movements {
 		tt.action.Perform(e)
-		if !assert.True(t, tt.expected.Equal(e.Actors[actor.ID].Position)) {
-			t.Error("Failure on the step", tt.name, tt.expected, e.Actors[actor.ID].Position)
+		moved, _ := e.Entity(player.ID)
+		if !assert.True(t, tt.expected.Equal(moved.Position)) {
+			t.Error("Failure on the step", tt.name, tt.expected, moved.Position)
 		}
 	}
 }
+
+func TestMoveActionPerformOnTiles(t *testing.T) {
+	player := game.Entity{
+		ID:   uuid.Must(uuid.NewV4()),
+		Name: "TestPlayer",
+		Position: game.Point{
+			X: 0,
+			Y: 0,
+		},
+		Health: game.Health{Life: 3},
+	}
+	e := &game.Engine{
+		GameMap: [][]rune{
+			{'█', '█', '█', '█', '█'},
+			{'█', ' ', '*', ' ', '█'},
+			{'█', ' ', '~', ' ', '█'},
+			{'█', ' ', ' ', ' ', '█'},
+			{'█', '█', '█', '█', '█'},
+		},
+	}
+	assert.Nil(t, game.SetPlayers(player)(e))
+	now := time.Now()
+	position := func() game.Point {
+		moved, _ := e.Entity(player.ID)
+		return moved.Position
+	}
+
+	// Once the player is back on the slow tile the next move should wait
+	(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionLeft, CreatedAt: now}).Perform(e)
+	(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionRight, CreatedAt: now.Add(10 * time.Millisecond)}).Perform(e)
+	(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionRight, CreatedAt: now.Add(20 * time.Millisecond)}).Perform(e)
+	assert.Equal(t, game.Point{X: 0, Y: 0}, position())
+	(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionUp, CreatedAt: now.Add(time.Second)}).Perform(e)
+	assert.Equal(t, game.Point{X: 0, Y: -1}, position())
+
+	// Entering on a hazard tile damages the player
+	damaged, _ := e.Entity(player.ID)
+	assert.Equal(t, 2, damaged.Health.Life)
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Weapon defines how the lasers shoot by a ship behave
+type Weapon struct {
+	Name string
+	// Damage is how many hit points the laser takes from his target
+	Damage int
+	// Speed is how long the laser needs for move from one position to the next
+	Speed time.Duration
+	// Cooldown is the minimum time between two shoots, the weapons without
+	// cooldown can shoot several lasers at once
+	Cooldown time.Duration
+}
+
+// DefaultWeapon is the laser gun used by the players
+var DefaultWeapon = Weapon{
+	Name:     "Laser",
+	Damage:   1,
+	Speed:    18 * time.Millisecond,
+	Cooldown: 120 * time.Millisecond,
+}
+
+// BotArchetype defines the stats, the weapon and the look of a kind of bot
+type BotArchetype struct {
+	Name string
+	Life int
+	// MoveInterval is the time between two movements, a bot with no interval
+	// can't move
+	MoveInterval time.Duration
+	// FireInterval is the time between two shoots, a bot with no interval
+	// can't shoot
+	FireInterval time.Duration
+	Weapon       Weapon
+	// ScoreValue is the amount of points given to the player who destroys the
+	// bot
+	ScoreValue int
+	Glyph      rune
+	// Color is the name of the color used for render the bot, it should be a
+	// W3C color name
+	Color string
+	// Footprint keeps the offsets from the bot position of all the cells
+	// occupied by the bot, an empty footprint means a single cell
+	Footprint []Point
+	// Phases keeps the attack phases of the bosses
+	Phases []BossPhase
+}
+
+// AutopilotArchetype keeps the intervals used by the players on autopilot,
+// the rest of the player stats don't change
+var AutopilotArchetype = BotArchetype{
+	Name:         "autopilot",
+	MoveInterval: 250 * time.Millisecond,
+	FireInterval: 600 * time.Millisecond,
+}
+
+// BotArchetypes keeps all the bot archetypes that can be used on the levels
+// indexed by his name
+var BotArchetypes = map[string]BotArchetype{
+	"grunt": {
+		Name:         "grunt",
+		Life:         4,
+		MoveInterval: 200 * time.Millisecond,
+		FireInterval: 900 * time.Millisecond,
+		Weapon:       DefaultWeapon,
+		ScoreValue:   50,
+		Glyph:        'Y',
+		Color:        "mediumaquamarine",
+	},
+	"tank": {
+		Name:         "tank",
+		Life:         10,
+		MoveInterval: 600 * time.Millisecond,
+		FireInterval: 1200 * time.Millisecond,
+		Weapon: Weapon{
+			Name:   "Cannon",
+			Damage: 2,
+			Speed:  30 * time.Millisecond,
+		},
+		ScoreValue: 150,
+		Glyph:      'H',
+		Color:      "olive",
+	},
+	"scout": {
+		Name:         "scout",
+		Life:         2,
+		MoveInterval: 100 * time.Millisecond,
+		FireInterval: 700 * time.Millisecond,
+		Weapon: Weapon{
+			Name:   "Blaster",
+			Damage: 1,
+			Speed:  12 * time.Millisecond,
+		},
+		ScoreValue: 75,
+		Glyph:      'V',
+		Color:      "orange",
+	},
+	"turret": {
+		Name:         "turret",
+		Life:         6,
+		FireInterval: 300 * time.Millisecond,
+		Weapon:       DefaultWeapon,
+		ScoreValue:   100,
+		Glyph:        'T',
+		Color:        "mediumpurple",
+	},
+	"boss": {
+		Name:         "boss",
+		Life:         60,
+		MoveInterval: 500 * time.Millisecond,
+		FireInterval: 700 * time.Millisecond,
+		Weapon: Weapon{
+			Name:   "Cannon",
+			Damage: 2,
+			Speed:  30 * time.Millisecond,
+		},
+		ScoreValue: 1000,
+		Glyph:      'W',
+		Color:      "crimson",
+		Footprint:  squareFootprint(1),
+		Phases: []BossPhase{
+			{Threshold: 1, Patterns: []BossPattern{PatternSpiral}},
+			{Threshold: 0.66, Patterns: []BossPattern{PatternSpiral, PatternSpiral, PatternSummon}},
+			{Threshold: 0.33, Patterns: []BossPattern{PatternCharge, PatternSpiral, PatternSpiral}},
+		},
+	},
+}
+
+// DefaultBotArchetype is the archetype used when a bot doesn't define one
+const DefaultBotArchetype = "grunt"
+
+// GetBotArchetype returns the bot archetype with the given name, an empty name
+// returns the default archetype
+func GetBotArchetype(name string) (BotArchetype, error) {
+	if name == "" {
+		name = DefaultBotArchetype
+	}
+	archetype, exists := BotArchetypes[name]
+	if !exists {
+		return BotArchetype{}, fmt.Errorf("Unknown bot archetype %s", name)
+	}
+	return archetype, nil
+}
@@ -0,0 +1,179 @@
+package game
+
+import (
+	"math/rand"
+	"time"
+)
+
+// BossPattern defines one of the attacks a boss can do
+type BossPattern int
+
+const (
+	// PatternSpiral shoots a laser on each one of the four directions
+	PatternSpiral BossPattern = iota
+	// PatternSummon spawns minions around the boss
+	PatternSummon
+	// PatternCharge moves the boss very fast on a random direction
+	PatternCharge
+)
+
+const (
+	// chargeSteps is how many positions a boss moves while charging
+	chargeSteps = 6
+	// chargeStepInterval is the time between two steps of a charge
+	chargeStepInterval = 50 * time.Millisecond
+	// maxMinions is the maximum amount of bots on the map for summon more
+	maxMinions = 10
+)
+
+// BossPhase defines the attacks of a boss while his life is equal or below a
+// fraction of his max life, the patterns are done one by one on each attack
+type BossPhase struct {
+	Threshold float64
+	Patterns  []BossPattern
+}
+
+// squareFootprint returns the offsets of a square of the given radius around
+// the origin
+func squareFootprint(radius int) (footprint []Point) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			footprint = append(footprint, Point{X: x, Y: y})
+		}
+	}
+	return footprint
+}
+
+// IsBoss returns wether the entity has attack phases
+func (en Entity) IsBoss() bool {
+	return len(en.Controller.Archetype.Phases) > 0
+}
+
+// Phase returns the current phase of a boss based on his life left, the
+// phases are expected from the highest threshold to the lowest one
+func (en Entity) Phase() (phase BossPhase) {
+	if !en.IsBoss() {
+		return phase
+	}
+	phases := en.Controller.Archetype.Phases
+	phase = phases[0]
+	fraction := float64(en.Health.Life) / float64(en.Health.MaxLife)
+	for _, p := range phases {
+		if fraction <= p.Threshold {
+			phase = p
+		}
+	}
+	return phase
+}
+
+// performBoss will move the boss on random directions and will attack with the
+// patterns of his current phase, on the intervals defined by his archetype
+func (e *Engine) performBoss(bot Entity) {
+	var movementTicks, attackTicks <-chan time.Time
+	archetype := bot.Controller.Archetype
+	if archetype.MoveInterval > 0 {
+		movementTicker := time.NewTicker(archetype.MoveInterval)
+		defer movementTicker.Stop()
+		movementTicks = movementTicker.C
+	}
+	if archetype.FireInterval > 0 {
+		attackTicker := time.NewTicker(archetype.FireInterval)
+		defer attackTicker.Stop()
+		attackTicks = attackTicker.C
+	}
+	attack := 0
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-movementTicks:
+			if _, exists := e.Entity(bot.ID); !exists {
+				return
+			}
+			if e.IsPaused() {
+				continue
+			}
+			e.Submit(&MoveAction{
+				EntityID:  bot.ID,
+				Direction: RandomDirection(),
+				CreatedAt: time.Now(),
+			})
+		case <-attackTicks:
+			bot, exists := e.Entity(bot.ID)
+			if !exists {
+				return
+			}
+			if e.IsPaused() {
+				continue
+			}
+			patterns := bot.Phase().Patterns
+			if len(patterns) == 0 {
+				continue
+			}
+			e.bossAttack(bot, patterns[attack%len(patterns)])
+			attack++
+		}
+	}
+}
+
+// bossAttack will execute the given pattern for the given boss
+func (e *Engine) bossAttack(bot Entity, pattern BossPattern) {
+	switch pattern {
+	case PatternSpiral:
+		for _, direction := range []Direction{DirectionUp, DirectionRight, DirectionDown, DirectionLeft} {
+			e.shootLaser(bot, direction)
+		}
+	case PatternSummon:
+		if e.botCount() >= maxMinions {
+			return
+		}
+		for _, position := range e.summonPositions(bot) {
+			minion, err := e.storeBot(BotSpawn{Strategy: ShootAndMoveStrategy}, position)
+			if err != nil {
+				return
+			}
+			go minion.Controller.Strategy.perform(e, minion)
+		}
+	case PatternCharge:
+		direction := RandomDirection()
+		for step := 0; step < chargeSteps; step++ {
+			select {
+			case <-e.done:
+				return
+			case <-time.After(chargeStepInterval):
+			}
+			e.Submit(&MoveAction{
+				EntityID:  bot.ID,
+				Direction: direction,
+				CreatedAt: time.Now(),
+			})
+		}
+	}
+}
+
+// summonPositions returns up to two free positions next to the boss where the
+// minions can appear
+func (e *Engine) summonPositions(bot Entity) (positions []Point) {
+	radius := 1
+	for _, offset := range bot.Footprint {
+		if offset.X > radius-1 {
+			radius = offset.X + 1
+		}
+	}
+	candidates := []Point{{X: radius}, {X: -radius}, {Y: radius}, {Y: -radius}}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	for _, offset := range candidates {
+		position := bot.Position.Add(offset)
+		if e.GameMap.ElementAt(position) == MapElementNone {
+			positions = append(positions, position)
+		}
+		if len(positions) == 2 {
+			break
+		}
+	}
+	return positions
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var mapTestBoss = [][]rune{
+	{'█', '█', '█', '█', '█', '█', '█'},
+	{'█', ' ', ' ', ' ', ' ', ' ', '█'},
+	{'█', ' ', ' ', ' ', ' ', ' ', '█'},
+	{'█', ' ', ' ', 'S', ' ', ' ', '█'},
+	{'█', ' ', ' ', ' ', ' ', ' ', '█'},
+	{'█', ' ', ' ', ' ', ' ', ' ', '█'},
+	{'█', '█', '█', '█', '█', '█', '█'},
+}
+
+func TestBossPhase(t *testing.T) {
+	archetype := BotArchetypes["boss"]
+	tests := []struct {
+		name     string
+		life     int
+		expected BossPhase
+	}{
+		{
+			name:     "Should be on the first phase with full life",
+			life:     60,
+			expected: archetype.Phases[0],
+		},
+		{
+			name:     "Should be on the second phase below two thirds of life",
+			life:     39,
+			expected: archetype.Phases[1],
+		},
+		{
+			name:     "Should be on the last phase below one third of life",
+			life:     10,
+			expected: archetype.Phases[2],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := Entity{
+				Health:     Health{Life: tt.life, MaxLife: archetype.Life},
+				Controller: Controller{Kind: ControllerBot, Archetype: archetype},
+			}
+			assert.Equal(t, tt.expected, bot.Phase())
+		})
+	}
+}
+
+func TestBossFootprint(t *testing.T) {
+	e := &Engine{GameMap: mapTestBoss}
+	assert.Nil(t, SetBotSpawns([]BotSpawn{{Strategy: BossStrategy, Archetype: "boss"}})(e))
+	var boss Entity
+	e.RangeEntities(func(entity Entity) bool {
+		boss = entity
+		return false
+	})
+	assert.Equal(t, 9, len(boss.Cells()))
+	assert.True(t, boss.Occupies(Point{X: 1, Y: 1}))
+	assert.False(t, boss.Occupies(Point{X: 2, Y: 0}))
+
+	// The boss can move while all his cells are free
+	now := time.Now()
+	(&MoveAction{EntityID: boss.ID, Direction: DirectionRight, CreatedAt: now}).Perform(e)
+	(&MoveAction{EntityID: boss.ID, Direction: DirectionRight, CreatedAt: now}).Perform(e)
+	boss, _ = e.Entity(boss.ID)
+	assert.Equal(t, Point{X: 1, Y: 0}, boss.Position)
+
+	// A laser hitting any of his cells damages the boss
+	assert.True(t, e.checkLaserCollisions(Laser{
+		ID:        uuid.Must(uuid.NewV4()),
+		Position:  Point{X: 2, Y: -1},
+		ShooterID: uuid.Must(uuid.NewV4()),
+		Team:      TeamPlayers,
+		Damage:    5,
+	}))
+	boss, _ = e.Entity(boss.ID)
+	assert.Equal(t, 55, boss.Health.Life)
+}
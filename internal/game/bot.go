@@ -21,132 +21,179 @@ const (
 	OnlyShootingStrategy
 	// ShootAndMoveStrategy define the bot will be in movement and shooting
 	ShootAndMoveStrategy
+	// BossStrategy define the bot will be in movement and attacking with the
+	// patterns of his current phase
+	BossStrategy
 )
 
-// Bot represents the basic information needed for handle all the AI enemies on
-// the game
-type Bot struct {
-	ID       uuid.UUID
-	Life     int
-	Position Point
-	Strategy BotStrategy
+// String returns the name of the strategy
+func (s BotStrategy) String() string {
+	switch s {
+	case OnlyMovementStrategy:
+		return "only movement"
+	case OnlyShootingStrategy:
+		return "only shooting"
+	case ShootAndMoveStrategy:
+		return "shoot and move"
+	case BossStrategy:
+		return "boss"
+	}
+	return "no movement"
+}
+
+// BotSpawn defines the bot that appears on a spawn position, the archetype is
+// the name of one of the BotArchetypes
+type BotSpawn struct {
+	Strategy  BotStrategy
+	Archetype string
 }
 
 // SetBots will receive an slice of bot strategies, this slice should match in
-// size with the expected numbers of spawn positions
+// size with the expected numbers of spawn positions, all the bots will use the
+// default archetype
 func SetBots(strategies []BotStrategy) engineOpt {
+	spawns := make([]BotSpawn, len(strategies))
+	for index, strategy := range strategies {
+		spawns[index] = BotSpawn{Strategy: strategy}
+	}
+	return SetBotSpawns(spawns)
+}
+
+// SetBotSpawns will receive an slice of bot spawns, this slice should match in
+// size with the expected numbers of spawn positions
+func SetBotSpawns(spawns []BotSpawn) engineOpt {
 	return func(e *Engine) error {
 		spawnElements := e.GameMap.GetMapElements()[MapElementSpawn]
-		if len(strategies) != len(spawnElements) {
-			return fmt.Errorf("Expected %d bots but received %d", len(spawnElements), len(strategies))
+		if len(spawns) != len(spawnElements) {
+			return fmt.Errorf("Expected %d bots but received %d", len(spawnElements), len(spawns))
 		}
-		for index, spawnPosition := range spawnElements {
-			botID := uuid.Must(uuid.NewV4())
-			e.Bots.Store(botID, Bot{
-				ID:       botID,
-				Life:     4,
-				Position: spawnPosition,
-				Strategy: strategies[index],
-			})
+		// The difficulty decides how many of the bots appear
+		scaled, positions := e.Difficulty.Settings().scaleBots(spawns)
+		for index, spawn := range scaled {
+			if _, err := e.storeBot(spawn, spawnElements[positions[index]]); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 }
 
-// botCount is a workaround I should do because there is not implemented yet
-// this len(sync.Map). For more info visit this issue https://github.com/golang/go/issues/20680
-// maybe exposing an atomic int will be enough, but I don't have access to it
-// this is why I need to range over the bots, of course is very inefficient
+// storeBot will create a new bot from the given spawn on the given position
+// and will add it to the engine, the strategy is not started yet. The bot
+// archetype is scaled by the engine difficulty
+func (e *Engine) storeBot(spawn BotSpawn, position Point) (Entity, error) {
+	archetype, err := GetBotArchetype(spawn.Archetype)
+	if err != nil {
+		return Entity{}, err
+	}
+	archetype = e.Difficulty.Settings().scaleArchetype(archetype)
+	bot := Entity{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      archetype.Name,
+		Position:  position,
+		Footprint: archetype.Footprint,
+		Health: Health{
+			Life:          archetype.Life,
+			MaxLife:       archetype.Life,
+			Lives:         1,
+			SpawnPosition: position,
+		},
+		Controller: Controller{
+			Kind:      ControllerBot,
+			Strategy:  spawn.Strategy,
+			Archetype: archetype,
+		},
+		Weapon: archetype.Weapon,
+		Team:   TeamBots,
+		Renderable: Renderable{
+			Glyph: archetype.Glyph,
+			Color: archetype.Color,
+		},
+	}
+	e.storeEntity(bot)
+	return bot, nil
+}
+
+// botCount returns how many bots are left on the map, the sync.Map has no len
+// so we need to range over all the entities
 func (e *Engine) botCount() (length int) {
-	e.Bots.Range(func(key interface{}, value interface{}) bool {
-		length++
+	e.RangeEntities(func(entity Entity) bool {
+		if !entity.IsPlayer() {
+			length++
+		}
 		return true
 	})
 	return length
 }
 
-// startBots will apply all the strategies linked to each bot
+// startBots will apply all the strategies linked to each bot and to each
+// player on autopilot
 func (e *Engine) startBots() {
-	e.Bots.Range(func(key interface{}, value interface{}) bool {
-		bot := value.(Bot)
-		go bot.Strategy.perform(e, bot)
+	e.RangeEntities(func(entity Entity) bool {
+		if !entity.IsPlayer() || entity.Controller.Autopilot {
+			go entity.Controller.Strategy.perform(e, entity)
+		}
 		return true
 	})
 }
 
-// perform will execute the behaviour linked to the given strategy
-func (s BotStrategy) perform(e *Engine, bot Bot) {
-	switch s {
-	case OnlyMovementStrategy:
-		ticker := time.NewTicker(200 * time.Millisecond)
-		for {
-			if _, exists := e.Bots.Load(bot.ID); !exists {
-				return
-			}
-			select {
-			case <-ticker.C:
-				e.ActionChan <- &BotMoveAction{
-					BotID:     bot.ID,
-					Direction: RandomDirection(),
-					CreatedAt: time.Now(),
-				}
-			default:
-			}
-		}
-	case OnlyShootingStrategy:
-		ticker := time.NewTicker(300 * time.Millisecond)
-		for {
-			b, exists := e.Bots.Load(bot.ID)
-			if !exists {
+// perform will execute the behaviour linked to the given strategy, the bot
+// moves and shoots on the intervals defined by his archetype
+func (s BotStrategy) perform(e *Engine, bot Entity) {
+	if s == BossStrategy {
+		e.performBoss(bot)
+		return
+	}
+	var movementTicks, shootingTicks <-chan time.Time
+	archetype := bot.Controller.Archetype
+	if (s == OnlyMovementStrategy || s == ShootAndMoveStrategy) && archetype.MoveInterval > 0 {
+		movementTicker := time.NewTicker(archetype.MoveInterval)
+		defer movementTicker.Stop()
+		movementTicks = movementTicker.C
+	}
+	if (s == OnlyShootingStrategy || s == ShootAndMoveStrategy) && archetype.FireInterval > 0 {
+		shootingTicker := time.NewTicker(archetype.FireInterval)
+		defer shootingTicker.Stop()
+		shootingTicks = shootingTicker.C
+	}
+	if movementTicks == nil && shootingTicks == nil {
+		return
+	}
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-movementTicks:
+			if _, exists := e.Entity(bot.ID); !exists {
 				return
 			}
-			bot := b.(Bot)
-			select {
-			case <-ticker.C:
-				laserID := uuid.Must(uuid.NewV4())
-				e.Lasers.Store(laserID, Laser{
-					ID:       laserID,
-					Position: bot.Position,
-					Origin:   OriginBot,
-				})
-				e.ActionChan <- &LaserAction{
-					LaserID:   laserID,
-					Direction: RandomDirection(),
-					CreatedAt: time.Now(),
-				}
-			default:
+			if e.IsPaused() {
+				continue
 			}
-		}
-	case ShootAndMoveStrategy:
-		movementTicker := time.NewTicker(200 * time.Millisecond)
-		shootingTicker := time.NewTicker(900 * time.Millisecond)
-		for {
-			b, exists := e.Bots.Load(bot.ID)
+			e.Submit(&MoveAction{
+				EntityID:  bot.ID,
+				Direction: RandomDirection(),
+				CreatedAt: time.Now(),
+			})
+		case <-shootingTicks:
+			bot, exists := e.Entity(bot.ID)
 			if !exists {
 				return
 			}
-			bot := b.(Bot)
-			select {
-			case <-movementTicker.C:
-				e.ActionChan <- &BotMoveAction{
-					BotID:     bot.ID,
-					Direction: RandomDirection(),
-					CreatedAt: time.Now(),
-				}
-			case <-shootingTicker.C:
-				laserID := uuid.Must(uuid.NewV4())
-				e.Lasers.Store(laserID, Laser{
-					ID:       laserID,
-					Position: bot.Position,
-					Origin:   OriginBot,
-				})
-				e.ActionChan <- &LaserAction{
-					LaserID:   laserID,
-					Direction: RandomDirection(),
-					CreatedAt: time.Now(),
-				}
-			default:
+			if e.IsPaused() {
+				continue
 			}
+			e.shootLaser(bot, e.aimDirection(bot))
 		}
 	}
 }
+
+// shootLaser will ask the engine to shoot the bot weapon on the given
+// direction
+func (e *Engine) shootLaser(bot Entity, direction Direction) {
+	e.Submit(&FireAction{
+		ShooterID: bot.ID,
+		Direction: direction,
+		CreatedAt: time.Now(),
+	})
+}
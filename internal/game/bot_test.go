@@ -61,3 +61,24 @@ func TestSetBots(t *testing.T) {
 		})
 	}
 }
+
+func TestSetBotSpawns(t *testing.T) {
+	e := &Engine{GameMap: mapTest}
+	err := SetBotSpawns([]BotSpawn{
+		{Strategy: OnlyShootingStrategy, Archetype: "turret"},
+		{Strategy: ShootAndMoveStrategy},
+	})(e)
+	assert.Nil(t, err)
+	lifes := make(map[string]int)
+	e.RangeEntities(func(bot Entity) bool {
+		lifes[bot.Controller.Archetype.Name] = bot.Health.Life
+		return true
+	})
+	assert.Equal(t, map[string]int{"turret": 6, "grunt": 4}, lifes)
+
+	err = SetBotSpawns([]BotSpawn{
+		{Strategy: OnlyShootingStrategy, Archetype: "dragon"},
+		{Strategy: ShootAndMoveStrategy},
+	})(&Engine{GameMap: mapTest})
+	assert.Equal(t, fmt.Errorf("Unknown bot archetype dragon"), err)
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"runtime"
+	"time"
+)
+
+// DebugInfo keeps a snapshot of the engine internals used for find
+// performance issues
+type DebugInfo struct {
+	// TickTime is the average time the engine needs for perform an action
+	TickTime time.Duration
+	// QueuedActions is how many actions are waiting on the action channel
+	QueuedActions int
+	// QueueCapacity is how many actions fit on the action channel
+	QueueCapacity int
+	// Input keeps how many actions were dropped, coalesced or too old
+	Input InputCounters
+	// Rejected is how many actions were not valid
+	Rejected   int
+	Lasers     int
+	Bots       int
+	Goroutines int
+}
+
+// DebugInfo returns a snapshot of the engine internals
+func (e *Engine) DebugInfo() DebugInfo {
+	lasers := 0
+	e.Lasers.Range(func(key interface{}, value interface{}) bool {
+		lasers++
+		return true
+	})
+	rejected := 0
+	for _, count := range e.Rejected() {
+		rejected += count
+	}
+	// The tick time is recorded on the engine goroutine with the engine locked
+	e.mutex.RLock()
+	tickTime := e.TickTime
+	e.mutex.RUnlock()
+	return DebugInfo{
+		TickTime:      tickTime,
+		QueuedActions: len(e.ActionChan),
+		QueueCapacity: cap(e.ActionChan),
+		Input:         e.InputCounters(),
+		Rejected:      rejected,
+		Lasers:        lasers,
+		Bots:          e.botCount(),
+		Goroutines:    runtime.NumGoroutine(),
+	}
+}
+
+// recordTickTime will add the time spent performing an action to the average,
+// the last actions weight more than the old ones. It should be called with the
+// engine locked
+func (e *Engine) recordTickTime(elapsed time.Duration) {
+	e.TickTime = (e.TickTime*7 + elapsed) / 8
+}
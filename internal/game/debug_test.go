@@ -0,0 +1,36 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugInfo(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Health: Health{Life: 3}, Position: Point{X: 1, Y: 0}}),
+	)
+	assert.Nil(t, err)
+	e.Start()
+	defer e.Stop()
+
+	// The overlay reads the tick time while the engine records it
+	for i := 0; i < 20; i++ {
+		e.Submit(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: time.Now()})
+		e.DebugInfo()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for e.DebugInfo().TickTime == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("The tick time was never recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	info := e.DebugInfo()
+	assert.Equal(t, cap(e.ActionChan), info.QueueCapacity)
+	assert.Zero(t, info.Lasers)
+}
@@ -0,0 +1,214 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Difficulty defines how hard is the game, it scales the bots, the damage and
+// the timing of the game
+type Difficulty int
+
+const (
+	// DifficultyNormal is the game as it was designed
+	DifficultyNormal Difficulty = iota
+	// DifficultyEasy has fewer, weaker and slower bots
+	DifficultyEasy
+	// DifficultyHard has stronger, faster and more accurate bots
+	DifficultyHard
+	// DifficultyNightmare has more bots with double damage and the players have
+	// less life
+	DifficultyNightmare
+)
+
+// Difficulties keeps all the difficulties from the easiest to the hardest one
+var Difficulties = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyNightmare}
+
+// DifficultySettings keeps the values scaled by a difficulty
+type DifficultySettings struct {
+	// BotLife scales the life of the bots
+	BotLife float64
+	// Interval scales the time between the movements and the shoots of the
+	// bots, a lower value means faster bots
+	Interval float64
+	// Damage scales the damage of the bot weapons
+	Damage float64
+	// PlayerLife is the amount of hit points for each life of the players
+	PlayerLife int
+	// Accuracy is the probability of a bot aiming to the nearest player
+	// instead of shooting on a random direction
+	Accuracy float64
+	// BotCount scales how many bots appear on the levels and the waves
+	BotCount float64
+}
+
+// difficultySettings keeps the settings for each one of the difficulties
+var difficultySettings = map[Difficulty]DifficultySettings{
+	DifficultyEasy: {
+		BotLife:    0.75,
+		Interval:   1.5,
+		Damage:     1,
+		PlayerLife: 5,
+		Accuracy:   0,
+		BotCount:   0.6,
+	},
+	DifficultyNormal: {
+		BotLife:    1,
+		Interval:   1,
+		Damage:     1,
+		PlayerLife: playerLife,
+		Accuracy:   0.1,
+		BotCount:   1,
+	},
+	DifficultyHard: {
+		BotLife:    1.5,
+		Interval:   0.75,
+		Damage:     1,
+		PlayerLife: playerLife,
+		Accuracy:   0.35,
+		BotCount:   1,
+	},
+	DifficultyNightmare: {
+		BotLife:    2,
+		Interval:   0.5,
+		Damage:     2,
+		PlayerLife: 2,
+		Accuracy:   0.6,
+		BotCount:   1.5,
+	},
+}
+
+// String returns the name of the difficulty
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyEasy:
+		return "Easy"
+	case DifficultyHard:
+		return "Hard"
+	case DifficultyNightmare:
+		return "Nightmare"
+	}
+	return "Normal"
+}
+
+// Settings returns the values scaled by the difficulty
+func (d Difficulty) Settings() DifficultySettings {
+	settings, exists := difficultySettings[d]
+	if !exists {
+		return difficultySettings[DifficultyNormal]
+	}
+	return settings
+}
+
+// ParseDifficulty returns the difficulty with the given name, the name is not
+// case sensitive
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, difficulty := range Difficulties {
+		if strings.EqualFold(difficulty.String(), name) {
+			return difficulty, nil
+		}
+	}
+	return DifficultyNormal, fmt.Errorf("Unknown difficulty %s", name)
+}
+
+// SetDifficulty will attach the given difficulty to the game engine, it needs
+// to be set before the level for scale the bots
+func SetDifficulty(d Difficulty) engineOpt {
+	return func(e *Engine) error {
+		e.Difficulty = d
+		return nil
+	}
+}
+
+// scaleArchetype returns the given archetype with his life, intervals and
+// weapon damage scaled
+func (s DifficultySettings) scaleArchetype(a BotArchetype) BotArchetype {
+	a.Life = scaleInt(a.Life, s.BotLife)
+	a.MoveInterval = time.Duration(float64(a.MoveInterval) * s.Interval)
+	a.FireInterval = time.Duration(float64(a.FireInterval) * s.Interval)
+	a.Weapon.Damage = scaleInt(a.Weapon.Damage, s.Damage)
+	return a
+}
+
+// scaleBots returns the given spawns scaled by the bot count, when there are
+// fewer bots the bosses are always kept and when there are more bots the
+// extra ones are copies of the non boss spawns. The positions are the index of
+// the spawn position for each bot
+func (s DifficultySettings) scaleBots(spawns []BotSpawn) (scaled []BotSpawn, positions []int) {
+	target := scaleInt(len(spawns), s.BotCount)
+	regulars := 0
+	for index, spawn := range spawns {
+		if spawn.Strategy != BossStrategy {
+			if regulars >= target {
+				continue
+			}
+			regulars++
+		}
+		scaled = append(scaled, spawn)
+		positions = append(positions, index)
+	}
+	for index := 0; len(scaled) < target && index < target*len(spawns); index++ {
+		spawn := spawns[index%len(spawns)]
+		if spawn.Strategy == BossStrategy {
+			continue
+		}
+		scaled = append(scaled, spawn)
+		positions = append(positions, index%len(spawns))
+	}
+	return scaled, positions
+}
+
+// scaleInt returns the given value scaled by the given factor, any positive
+// value is kept at least as one
+func scaleInt(value int, factor float64) int {
+	if value <= 0 {
+		return value
+	}
+	scaled := int(math.Round(float64(value) * factor))
+	if scaled < 1 {
+		return 1
+	}
+	return scaled
+}
+
+// aimDirection returns the direction where the given bot shoots, depending on
+// the accuracy the bot aims to the nearest target on his same row or column or
+// shoots on a random direction. With the fog of war the bot only aims to the
+// targets he can see
+func (e *Engine) aimDirection(bot Entity) Direction {
+	if rand.Float64() >= e.Difficulty.Settings().Accuracy {
+		return RandomDirection()
+	}
+	direction, distance := RandomDirection(), math.MaxInt32
+	e.RangeEntities(func(target Entity) bool {
+		if target.Health.Eliminated || !e.isTarget(bot, target) || (e.Rules.FogOfWar && !e.CanSee(bot, target.Position)) {
+			return true
+		}
+		dx, dy := target.Position.X-bot.Position.X, target.Position.Y-bot.Position.Y
+		switch {
+		case dx == 0 && dy < 0 && -dy < distance:
+			direction, distance = DirectionUp, -dy
+		case dx == 0 && dy > 0 && dy < distance:
+			direction, distance = DirectionDown, dy
+		case dy == 0 && dx < 0 && -dx < distance:
+			direction, distance = DirectionLeft, -dx
+		case dy == 0 && dx > 0 && dx < distance:
+			direction, distance = DirectionRight, dx
+		}
+		return true
+	})
+	return direction
+}
+
+// isTarget returns wether the given shooter aims to the given target, the
+// bots aim to the players and the players on autopilot aim to anyone their
+// lasers can damage
+func (e *Engine) isTarget(shooter Entity, target Entity) bool {
+	if !shooter.IsPlayer() {
+		return target.IsPlayer()
+	}
+	return e.isHostile(shooter.ID, shooter.Team, target.ID, target.Team)
+}
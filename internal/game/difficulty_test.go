@@ -0,0 +1,150 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	difficulty, err := ParseDifficulty("nightmare")
+	assert.Nil(t, err)
+	assert.Equal(t, DifficultyNightmare, difficulty)
+	difficulty, err = ParseDifficulty("Easy")
+	assert.Nil(t, err)
+	assert.Equal(t, DifficultyEasy, difficulty)
+	_, err = ParseDifficulty("impossible")
+	assert.NotNil(t, err)
+}
+
+func TestScaleArchetype(t *testing.T) {
+	tank := BotArchetypes["tank"]
+	tests := []struct {
+		name         string
+		difficulty   Difficulty
+		life         int
+		moveInterval time.Duration
+		damage       int
+	}{
+		{
+			name:         "Should keep the archetype on normal",
+			difficulty:   DifficultyNormal,
+			life:         tank.Life,
+			moveInterval: tank.MoveInterval,
+			damage:       tank.Weapon.Damage,
+		},
+		{
+			name:         "Should be weaker and slower on easy",
+			difficulty:   DifficultyEasy,
+			life:         8,
+			moveInterval: 900 * time.Millisecond,
+			damage:       tank.Weapon.Damage,
+		},
+		{
+			name:         "Should be stronger and faster on nightmare",
+			difficulty:   DifficultyNightmare,
+			life:         20,
+			moveInterval: 300 * time.Millisecond,
+			damage:       4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archetype := tt.difficulty.Settings().scaleArchetype(tank)
+			assert.Equal(t, tt.life, archetype.Life)
+			assert.Equal(t, tt.moveInterval, archetype.MoveInterval)
+			assert.Equal(t, tt.damage, archetype.Weapon.Damage)
+		})
+	}
+}
+
+func TestScaleBots(t *testing.T) {
+	boss := BotSpawn{Strategy: BossStrategy, Archetype: "boss"}
+	grunt := BotSpawn{Strategy: ShootAndMoveStrategy}
+	tests := []struct {
+		name      string
+		count     float64
+		spawns    []BotSpawn
+		expected  []BotSpawn
+		positions []int
+	}{
+		{
+			name:      "Should keep all the bots",
+			count:     1,
+			spawns:    []BotSpawn{grunt, boss},
+			expected:  []BotSpawn{grunt, boss},
+			positions: []int{0, 1},
+		},
+		{
+			name:      "Should drop bots but never the boss",
+			count:     0.5,
+			spawns:    []BotSpawn{boss, grunt, grunt, grunt},
+			expected:  []BotSpawn{boss, grunt, grunt},
+			positions: []int{0, 1, 2},
+		},
+		{
+			name:      "Should keep at least one bot",
+			count:     0.1,
+			spawns:    []BotSpawn{grunt, grunt},
+			expected:  []BotSpawn{grunt},
+			positions: []int{0},
+		},
+		{
+			name:      "Should copy the bots except the boss",
+			count:     1.5,
+			spawns:    []BotSpawn{boss, grunt},
+			expected:  []BotSpawn{boss, grunt, grunt},
+			positions: []int{0, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled, positions := DifficultySettings{BotCount: tt.count}.scaleBots(tt.spawns)
+			assert.Equal(t, tt.expected, scaled)
+			assert.Equal(t, tt.positions, positions)
+		})
+	}
+}
+
+func TestAimDirection(t *testing.T) {
+	bot := Entity{Position: Point{X: 0, Y: 0}, Controller: Controller{Kind: ControllerBot}}
+	e := &Engine{Difficulty: DifficultyNightmare}
+	for _, player := range []Entity{
+		{ID: uuid.Must(uuid.NewV4()), Position: Point{X: 0, Y: 5}},
+		{ID: uuid.Must(uuid.NewV4()), Position: Point{X: -2, Y: 0}},
+		{ID: uuid.Must(uuid.NewV4()), Position: Point{X: 1, Y: 0}, Health: Health{Eliminated: true}},
+	} {
+		e.storeEntity(player)
+	}
+	original := difficultySettings[DifficultyNightmare]
+	difficultySettings[DifficultyNightmare] = DifficultySettings{Accuracy: 1}
+	defer func() { difficultySettings[DifficultyNightmare] = original }()
+	assert.Equal(t, DirectionLeft, e.aimDirection(bot))
+}
+
+func TestIsTarget(t *testing.T) {
+	bot := Entity{ID: uuid.Must(uuid.NewV4()), Controller: Controller{Kind: ControllerBot}, Team: TeamBots}
+	otherBot := Entity{ID: uuid.Must(uuid.NewV4()), Controller: Controller{Kind: ControllerBot}, Team: TeamBots}
+	pilot := Entity{ID: uuid.Must(uuid.NewV4()), Controller: Controller{Autopilot: true}, Team: TeamPlayers}
+	ally := Entity{ID: uuid.Must(uuid.NewV4()), Team: TeamPlayers}
+	tests := []struct {
+		name     string
+		shooter  Entity
+		target   Entity
+		expected bool
+	}{
+		{name: "The bots should aim to the players", shooter: bot, target: ally, expected: true},
+		{name: "The bots shouldn't aim to the other bots", shooter: bot, target: otherBot, expected: false},
+		{name: "The autopilot should aim to the bots", shooter: pilot, target: bot, expected: true},
+		{name: "The autopilot shouldn't aim to his allies", shooter: pilot, target: ally, expected: false},
+		{name: "The autopilot shouldn't aim to himself", shooter: pilot, target: pilot, expected: false},
+	}
+	e := &Engine{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, e.isTarget(tt.shooter, tt.target))
+		})
+	}
+}
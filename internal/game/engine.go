@@ -1,8 +1,8 @@
 package game
 
 import (
-	"log"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -13,62 +13,198 @@ type engineOpt func(e *Engine) error
 
 // Engine type will keep all the main information related with the game
 type Engine struct {
-	// Actors keep the information and link about all the interactors of the
-	// game
-	Actors map[uuid.UUID]Actor
+	// Entities keep the information about all the ships of the game, the
+	// players and the bots
+	Entities sync.Map
 	// GameMap keep link to the current map is playing
 	GameMap Map
 	// ActionChan is a buffered channel used for comunication between view and the
 	// engine
 	ActionChan chan Action
-	// Score keep the info related with points and actors
+	// Score keep the info related with points and players
 	Score map[uuid.UUID]int
 	// RoundWinner keep the id for the winner
 	RoundWinner uuid.UUID
+	// WinningTeam keep the team who won the team deathmatch
+	WinningTeam Team
+	// Rules keeps how the round is played
+	Rules Rules
+	// Difficulty keeps how hard is the game
+	Difficulty Difficulty
+	// StartedAt keeps when the engine was started
+	StartedAt time.Time
+	// FinishedAt keeps when the round was over
+	FinishedAt time.Time
+	// Stats keeps how each player is performing on the match
+	Stats map[uuid.UUID]*PlayerStats
+	// TickTime is the average time needed for perform the actions
+	TickTime time.Duration
+	// InputPolicy defines how the actions are dropped when the engine can't
+	// keep up with them
+	InputPolicy InputPolicy
+	// input keeps the movements waiting to be performed
+	input inputQueue
+	// OnReject is called each time an action is rejected, it can be nil
+	OnReject RejectHook
+	// validation keeps the rate limits and the rejected actions
+	validation validation
+	// Frags keeps how many enemies killed each player
+	Frags map[uuid.UUID]int
 	// LevelComplete is the flag that determines when the level is complete
 	LevelComplete bool
 	// GameOver is the flag that determines when the player dies
 	GameOver bool
 	// Lasers keep the information about each lasers on the map
 	Lasers sync.Map
-	// Bots keep the information about bots on the map
-	Bots sync.Map
+	// WaveSchedule keeps the waves of bots spawned on the survival mode
+	WaveSchedule WaveSchedule
+	// Wave is the number of the current wave on the survival mode
+	Wave int
+	// WavesSurvived is how many waves the players survived on the survival mode
+	WavesSurvived int
+	// LevelName keeps the name of the level is playing
+	LevelName string
+	// paused is the flag that determines when the game is stopped for a while,
+	// nothing moves until the game is resumed
+	paused bool
+	// mutex guards the state changed while the game is running, as the map
+	// tiles, the scores and the end of the round. The actions are performed
+	// with it locked
+	mutex sync.RWMutex
+	// done is closed when the engine is stopped
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
-// NewEngine function will build a new engine with the applied engine options
-func NewEngine(opts ...engineOpt) *Engine {
+// NewEngine function will build a new engine with the applied engine options,
+// it returns the error of the first option that can't be applied
+func NewEngine(opts ...engineOpt) (*Engine, error) {
 	e := &Engine{
-		ActionChan: make(chan Action, 100),
-		Score:      make(map[uuid.UUID]int),
+		ActionChan:  make(chan Action, 100),
+		Score:       make(map[uuid.UUID]int),
+		Frags:       make(map[uuid.UUID]int),
+		Rules:       Rules{FragLimit: DefaultFragLimit},
+		InputPolicy: DefaultInputPolicy,
+		done:        make(chan struct{}),
 	}
 	for _, fn := range opts {
 		if err := fn(e); err != nil {
-			log.Panic("Error while applying engineOpt", err)
+			return nil, err
 		}
 	}
-	return e
+	return e, nil
 }
 
 // Start will setup the basics for run the game
 func (e *Engine) Start() {
+	e.StartedAt = time.Now()
 	go e.actionsListener()
 	e.startBots()
+	if e.Rules.Mode == ModeSurvival {
+		go e.survivalLoop()
+	}
+}
+
+// Pause will stop the game for a while, nothing moves and the actions are
+// rejected until the game is resumed
+func (e *Engine) Pause() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.paused = true
+}
+
+// Resume will continue the game after a pause
+func (e *Engine) Resume() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.paused = false
+}
+
+// IsPaused returns true while the game is paused
+func (e *Engine) IsPaused() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.paused
+}
+
+// Stop will finish all the goroutines linked to the engine, once stopped the
+// engine can't be started again
+func (e *Engine) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.done)
+	})
 }
 
 // actionsListener will be listening for all the events received from the action
-// channel and will apply them
+// channel and will apply them, the actions not valid, as the ones received
+// while the game is paused, are discarded. The actions should be sent with
+// Submit for never block the sender
 func (e *Engine) actionsListener() {
 	for {
-		action := <-e.ActionChan
-		action.Perform(e)
+		select {
+		case action := <-e.ActionChan:
+			e.Perform(action)
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// RoundState keeps how the round is going at some point, the scores and the
+// end of the round change while the game is running so outside of the actions
+// they should be read from here
+type RoundState struct {
+	Score         map[uuid.UUID]int
+	Frags         map[uuid.UUID]int
+	RoundWinner   uuid.UUID
+	WinningTeam   Team
+	LevelComplete bool
+	GameOver      bool
+	Wave          int
+	WavesSurvived int
+}
+
+// Round returns a copy of the state of the round
+func (e *Engine) Round() RoundState {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	round := RoundState{
+		Score:         make(map[uuid.UUID]int, len(e.Score)),
+		Frags:         make(map[uuid.UUID]int, len(e.Frags)),
+		RoundWinner:   e.RoundWinner,
+		WinningTeam:   e.WinningTeam,
+		LevelComplete: e.LevelComplete,
+		GameOver:      e.GameOver,
+		Wave:          e.Wave,
+		WavesSurvived: e.WavesSurvived,
+	}
+	for id, score := range e.Score {
+		round.Score[id] = score
 	}
+	for id, frags := range e.Frags {
+		round.Frags[id] = frags
+	}
+	return round
 }
 
-// updateScores will update the global scores.
-// TODO refactor this, now we only have one player, but in case we have multiple
-// we will need to receive at least the actorID
-func (e *Engine) updateScores() {
-	for actorID := range e.Actors {
-		e.Score[actorID] += 10
+// updateScores will give the points of a hit to the given player, the ids that
+// doesn't belong to a player, as the bots ones, are ignored
+func (e *Engine) updateScores(playerID uuid.UUID) {
+	if !e.isPlayer(playerID) {
+		return
+	}
+	e.Score[playerID] += 10
+}
+
+// decideRoundWinner returns the player with the highest score, on a draw the
+// player with more lives left wins
+func (e *Engine) decideRoundWinner() (winner uuid.UUID) {
+	bestScore, bestLives := -1, -1
+	for _, player := range e.Players() {
+		score := e.Score[player.ID]
+		if score > bestScore || (score == bestScore && player.Health.Lives > bestLives) {
+			winner, bestScore, bestLives = player.ID, score, player.Health.Lives
+		}
 	}
+	return winner
 }
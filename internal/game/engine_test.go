@@ -0,0 +1,105 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecideRoundWinner(t *testing.T) {
+	first := uuid.Must(uuid.NewV4())
+	second := uuid.Must(uuid.NewV4())
+	tests := []struct {
+		name     string
+		score    map[uuid.UUID]int
+		lives    map[uuid.UUID]int
+		expected uuid.UUID
+	}{
+		{
+			name:     "Should win the player with the highest score",
+			score:    map[uuid.UUID]int{first: 10, second: 30},
+			lives:    map[uuid.UUID]int{first: 3, second: 1},
+			expected: second,
+		},
+		{
+			name:     "Should win the player with more lives on a draw",
+			score:    map[uuid.UUID]int{first: 20, second: 20},
+			lives:    map[uuid.UUID]int{first: 2, second: 1},
+			expected: first,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{Score: tt.score}
+			e.storeEntity(Entity{ID: first, Health: Health{Lives: tt.lives[first]}})
+			e.storeEntity(Entity{ID: second, Health: Health{Lives: tt.lives[second]}})
+			assert.Equal(t, tt.expected, e.decideRoundWinner())
+		})
+	}
+}
+
+func TestUpdateScores(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e := &Engine{Score: make(map[uuid.UUID]int)}
+	e.storeEntity(Entity{ID: playerID})
+	e.updateScores(playerID)
+	e.updateScores(uuid.Must(uuid.NewV4()))
+	assert.Equal(t, map[uuid.UUID]int{playerID: 10}, e.Score)
+}
+
+func TestRound(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e := &Engine{
+		Score:       map[uuid.UUID]int{playerID: 20},
+		Frags:       map[uuid.UUID]int{playerID: 2},
+		RoundWinner: playerID,
+		GameOver:    true,
+	}
+	round := e.Round()
+	round.Score[playerID] = 50
+	assert.Equal(t, 20, e.Score[playerID], "The round should keep a copy of the score")
+	assert.Equal(t, map[uuid.UUID]int{playerID: 2}, round.Frags)
+	assert.Equal(t, playerID, round.RoundWinner)
+	assert.True(t, round.GameOver)
+	assert.False(t, round.LevelComplete)
+}
+
+func TestNewEngine(t *testing.T) {
+	twoSpawns := Map{
+		[]rune("██████"),
+		[]rune("█1  2█"),
+		[]rune("██████"),
+	}
+	first, second, third := NewPlayer("First", DifficultyNormal), NewPlayer("Second", DifficultyNormal), NewPlayer("Third", DifficultyNormal)
+	e, err := NewEngine(SetMap(twoSpawns), SetPlayers(first, second))
+	assert.Nil(t, err)
+	assert.Len(t, e.Players(), 2)
+
+	e, err = NewEngine(SetMap(twoSpawns), SetPlayers(first, second, third))
+	assert.EqualError(t, err, "The map has 2 player spawn positions for 3 players")
+	assert.Nil(t, e)
+}
+
+func TestPause(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Health: Health{Life: 3}, Position: Point{X: 1, Y: 0}}),
+		SetBotSpawns([]BotSpawn{{Strategy: ShootAndMoveStrategy, Archetype: "scout"}, {Strategy: ShootAndMoveStrategy, Archetype: "turret"}}),
+	)
+	assert.Nil(t, err)
+	e.Start()
+	defer e.Stop()
+
+	// The bots check the pause while the user interface changes it
+	for i := 0; i < 10; i++ {
+		e.Pause()
+		assert.True(t, e.IsPaused())
+		assert.Equal(t, ErrPaused, e.Perform(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: time.Now()}))
+		e.Resume()
+		assert.False(t, e.IsPaused())
+		time.Sleep(5 * time.Millisecond)
+	}
+}
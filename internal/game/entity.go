@@ -0,0 +1,264 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// respawnInvulnerability is how long an entity can't be damaged after
+	// respawn
+	respawnInvulnerability = 2 * time.Second
+	// playerLife is the amount of hit points for each life of a new player
+	playerLife = 3
+	// playerLives is the amount of lives of a new player
+	playerLives = 3
+)
+
+// Entity is any ship on the game, the players and the bots are entities with
+// different components
+type Entity struct {
+	ID   uuid.UUID
+	Name string
+	// Position and Footprint define the cells occupied by the entity, the
+	// footprint keeps the offsets from the position and an empty footprint
+	// means a single cell
+	Position  Point
+	Footprint []Point
+	// LastMove keeps when the entity moved for last time
+	LastMove   time.Time
+	Health     Health
+	Controller Controller
+	Weapon     Weapon
+	// Team keeps the faction of the entity
+	Team       Team
+	Renderable Renderable
+}
+
+// Health keeps the life of an entity
+type Health struct {
+	// Life keeps the hit points left on the current life
+	Life int
+	// MaxLife keeps the hit points restored on each respawn
+	MaxLife int
+	// Lives keeps how many lives are left, including the current one
+	Lives int
+	// SpawnPosition is where the entity appears after lose a life
+	SpawnPosition Point
+	// InvulnerableUntil keeps until when the entity can't be damaged
+	InvulnerableUntil time.Time
+	// Eliminated is the flag that determines when a player lost all his
+	// lives, the bots are removed instead
+	Eliminated bool
+}
+
+// ControllerKind defines who decides the actions of an entity
+type ControllerKind int
+
+const (
+	// ControllerPlayer is an entity moved by a player
+	ControllerPlayer ControllerKind = iota
+	// ControllerBot is an entity moved by the engine following a strategy
+	ControllerBot
+)
+
+// Controller keeps who decides the actions of an entity, the bots have a
+// strategy and an archetype with their intervals, score value and phases
+type Controller struct {
+	Kind      ControllerKind
+	Strategy  BotStrategy
+	Archetype BotArchetype
+	// Autopilot is the flag that determines when a player is flown by the
+	// engine following his strategy, as the bots do, so a match can be
+	// watched without anyone playing it
+	Autopilot bool
+}
+
+// Renderable keeps how an entity looks
+type Renderable struct {
+	Glyph rune
+	// Color is the name of the color, it should be a W3C color name or an
+	// hex color as #rrggbb
+	Color string
+}
+
+// NewPlayer will build a new player entity with the given name and the default
+// lives and weapon, his life depends on the given difficulty
+func NewPlayer(name string, difficulty Difficulty) Entity {
+	life := difficulty.Settings().PlayerLife
+	return Entity{
+		ID:   uuid.Must(uuid.NewV4()),
+		Name: name,
+		Health: Health{
+			Life:    life,
+			MaxLife: life,
+			Lives:   playerLives,
+		},
+		Controller: Controller{Kind: ControllerPlayer},
+		Weapon:     DefaultWeapon,
+	}
+}
+
+// IsPlayer returns wether the entity is moved by a player
+func (en Entity) IsPlayer() bool {
+	return en.Controller.Kind == ControllerPlayer
+}
+
+// IsInvulnerable returns wether the entity can't be damaged at the given time
+func (en Entity) IsInvulnerable(now time.Time) bool {
+	return now.Before(en.Health.InvulnerableUntil)
+}
+
+// Cells returns all the positions occupied by the entity
+func (en Entity) Cells() []Point {
+	return en.cellsAt(en.Position)
+}
+
+// cellsAt returns the positions the entity would occupy on the given position
+func (en Entity) cellsAt(position Point) []Point {
+	if len(en.Footprint) == 0 {
+		return []Point{position}
+	}
+	cells := make([]Point, len(en.Footprint))
+	for i, offset := range en.Footprint {
+		cells[i] = position.Add(offset)
+	}
+	return cells
+}
+
+// Occupies returns wether any of the cells of the entity is on the given
+// position
+func (en Entity) Occupies(p Point) bool {
+	for _, cell := range en.Cells() {
+		if cell.Equal(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// killKind returns the name used on the stats for the kills of the entity,
+// the bot strategy or player for the players
+func (en Entity) killKind() string {
+	if en.IsPlayer() {
+		return playerKills
+	}
+	return en.Controller.Strategy.String()
+}
+
+// respawn will move the entity to his spawn position with the life restored
+// and a temporary invulnerability
+func (en *Entity) respawn(now time.Time) {
+	en.Position = en.Health.SpawnPosition
+	en.Health.Life = en.Health.MaxLife
+	en.Health.InvulnerableUntil = now.Add(respawnInvulnerability)
+}
+
+// SetPlayers will attach the given players to the game engine, the players
+// without lives defined will have only one, the players without team will be on
+// the players team, the players without weapon will have the default one and
+// the players will respawn on the position where they start. The players on
+// autopilot without archetype fly with the autopilot one. When the map has
+// player spawn positions each player starts on his own one, so the map needs
+// to be set before the players. Without them the players keep their positions,
+// which can't be on a wall
+func SetPlayers(players ...Entity) engineOpt {
+	return func(e *Engine) error {
+		spawns := e.GameMap.PlayerSpawns()
+		if len(spawns) > 0 && len(spawns) < len(players) {
+			return fmt.Errorf("The map has %d player spawn positions for %d players", len(spawns), len(players))
+		}
+		for i := range players {
+			if len(spawns) > 0 {
+				players[i].Position = spawns[i]
+			}
+			if e.GameMap.IsBlocking(players[i].Position) {
+				return fmt.Errorf("The player %s starts on %q, the map needs a player spawn for each player", players[i].Name, e.GameMap.RuneAt(players[i].Position))
+			}
+		}
+		for _, player := range players {
+			e.storeEntity(preparePlayer(player))
+		}
+		return nil
+	}
+}
+
+// preparePlayer returns the given player with the defaults of SetPlayers
+// applied, the player will respawn on his current position
+func preparePlayer(player Entity) Entity {
+	player.Controller.Kind = ControllerPlayer
+	if player.Health.MaxLife == 0 {
+		player.Health.MaxLife = player.Health.Life
+	}
+	if player.Health.Lives == 0 {
+		player.Health.Lives = 1
+	}
+	if player.Team == TeamNone {
+		player.Team = TeamPlayers
+	}
+	if player.Weapon.Name == "" {
+		player.Weapon = DefaultWeapon
+	}
+	if player.Controller.Autopilot && player.Controller.Archetype.Name == "" {
+		player.Controller.Archetype = AutopilotArchetype
+	}
+	player.Health.SpawnPosition = player.Position
+	return player
+}
+
+// Entity returns the entity with the given id
+func (e *Engine) Entity(id uuid.UUID) (Entity, bool) {
+	value, exists := e.Entities.Load(id)
+	if !exists {
+		return Entity{}, false
+	}
+	return value.(Entity), true
+}
+
+// RangeEntities will call the given function for each entity until the
+// function returns false
+func (e *Engine) RangeEntities(fn func(entity Entity) bool) {
+	e.Entities.Range(func(key interface{}, value interface{}) bool {
+		return fn(value.(Entity))
+	})
+}
+
+// Players returns all the entities moved by a player, including the
+// eliminated ones
+func (e *Engine) Players() (players []Entity) {
+	e.RangeEntities(func(entity Entity) bool {
+		if entity.IsPlayer() {
+			players = append(players, entity)
+		}
+		return true
+	})
+	return players
+}
+
+// isPlayer returns wether the given id belongs to a player
+func (e *Engine) isPlayer(id uuid.UUID) bool {
+	entity, exists := e.Entity(id)
+	return exists && entity.IsPlayer()
+}
+
+// storeEntity will add or update the given entity on the engine
+func (e *Engine) storeEntity(entity Entity) {
+	e.Entities.Store(entity.ID, entity)
+}
+
+// removeEntity will remove the entity with the given id from the engine
+func (e *Engine) removeEntity(id uuid.UUID) {
+	e.Entities.Delete(id)
+}
+
+// allPlayersEliminated returns true when there is no player left with lives
+func (e *Engine) allPlayersEliminated() bool {
+	for _, player := range e.Players() {
+		if !player.Health.Eliminated {
+			return false
+		}
+	}
+	return true
+}
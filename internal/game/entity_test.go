@@ -0,0 +1,169 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDamagePlayer(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e := &Engine{GameMap: mapTest}
+	err := SetPlayers(Entity{
+		ID:   playerID,
+		Name: "TestPlayer",
+		Position: Point{
+			X: 1,
+			Y: 1,
+		},
+		Health: Health{
+			Life:  1,
+			Lives: 2,
+		},
+	})(e)
+	assert.Nil(t, err)
+
+	// Move the player away from his spawn before lose his first life
+	player, _ := e.Entity(playerID)
+	player.Position = Point{X: 2, Y: 2}
+	e.storeEntity(player)
+
+	e.damageEntity(playerID, uuid.Nil, 1)
+	player, _ = e.Entity(playerID)
+	assert.Equal(t, 1, player.Health.Lives)
+	assert.Equal(t, 1, player.Health.Life)
+	assert.Equal(t, Point{X: 1, Y: 1}, player.Position)
+	assert.False(t, player.Health.Eliminated)
+	assert.False(t, e.GameOver)
+
+	// The player is invulnerable just after respawn
+	e.damageEntity(playerID, uuid.Nil, 1)
+	player, _ = e.Entity(playerID)
+	assert.Equal(t, 1, player.Health.Lives)
+
+	player.Health.InvulnerableUntil = player.Health.InvulnerableUntil.Add(-respawnInvulnerability)
+	e.storeEntity(player)
+	e.damageEntity(playerID, uuid.Nil, 1)
+	player, _ = e.Entity(playerID)
+	assert.True(t, player.Health.Eliminated)
+	assert.True(t, e.GameOver)
+}
+
+func TestDamageBot(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e := &Engine{GameMap: mapTest, Score: make(map[uuid.UUID]int)}
+	assert.Nil(t, SetPlayers(Entity{ID: playerID, Health: Health{Life: 1}})(e))
+	assert.Nil(t, SetBotSpawns([]BotSpawn{
+		{Strategy: OnlyMovementStrategy, Archetype: "scout"},
+		{Strategy: OnlyShootingStrategy, Archetype: "turret"},
+	})(e))
+	var bot Entity
+	e.RangeEntities(func(entity Entity) bool {
+		if entity.Controller.Archetype.Name == "scout" {
+			bot = entity
+		}
+		return true
+	})
+
+	e.damageEntity(bot.ID, playerID, 1)
+	damaged, exists := e.Entity(bot.ID)
+	assert.True(t, exists)
+	assert.Equal(t, 1, damaged.Health.Life)
+	assert.False(t, e.LevelComplete)
+
+	// The destroyed bots are removed and the player gets his score
+	e.damageEntity(bot.ID, playerID, 1)
+	_, exists = e.Entity(bot.ID)
+	assert.False(t, exists)
+	assert.Equal(t, 75, e.Score[playerID])
+	assert.Equal(t, 1, e.botCount())
+	assert.False(t, e.LevelComplete)
+}
+
+func TestSetPlayersSpawns(t *testing.T) {
+	spawnsMap := Map{
+		[]rune("█████"),
+		[]rune("█2  █"),
+		[]rune("█   █"),
+		[]rune("█  1█"),
+		[]rune("█████"),
+	}
+	tests := []struct {
+		name      string
+		gameMap   Map
+		players   int
+		positions []Point
+		err       error
+	}{
+		{
+			name:      "Should place each player on his own spawn",
+			gameMap:   spawnsMap,
+			players:   2,
+			positions: []Point{{X: 1, Y: 1}, {X: -1, Y: -1}},
+		},
+		{
+			name:      "Should leave the spawns without player",
+			gameMap:   spawnsMap,
+			players:   1,
+			positions: []Point{{X: 1, Y: 1}},
+		},
+		{
+			name:    "Should fail when there are more players than spawns",
+			gameMap: spawnsMap,
+			players: 3,
+			err:     fmt.Errorf("The map has 2 player spawn positions for 3 players"),
+		},
+		{
+			name:      "Should keep the positions on the maps without player spawns",
+			gameMap:   mapTest1,
+			players:   2,
+			positions: []Point{{X: 2, Y: 0}, {X: 2, Y: 0}},
+		},
+		{
+			name: "Should fail when a player starts on a wall of a map without player spawns",
+			gameMap: Map{
+				[]rune("█████"),
+				[]rune("█   █"),
+				[]rune("█   █"),
+				[]rune("█   █"),
+				[]rune("█████"),
+			},
+			players: 1,
+			err:     fmt.Errorf("The player Player 1 starts on '█', the map needs a player spawn for each player"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{GameMap: tt.gameMap}
+			var players []Entity
+			for i := 0; i < tt.players; i++ {
+				players = append(players, NewPlayer(fmt.Sprintf("Player %d", i+1), DifficultyNormal))
+				players[i].Position = Point{X: 2, Y: 0}
+			}
+			assert.Equal(t, tt.err, SetPlayers(players...)(e))
+			for i, position := range tt.positions {
+				player, exists := e.Entity(players[i].ID)
+				assert.True(t, exists)
+				assert.Equal(t, position, player.Position)
+				assert.Equal(t, position, player.Health.SpawnPosition, "The player should respawn on his spawn")
+			}
+		})
+	}
+}
+
+func TestSetPlayersAutopilot(t *testing.T) {
+	e := &Engine{GameMap: mapTest}
+	pilot := NewPlayer("Pilot", DifficultyNormal)
+	pilot.Controller = Controller{Autopilot: true, Strategy: ShootAndMoveStrategy}
+	human := NewPlayer("Human", DifficultyNormal)
+	assert.Nil(t, SetPlayers(pilot, human)(e))
+
+	pilot, _ = e.Entity(pilot.ID)
+	assert.Equal(t, ControllerPlayer, pilot.Controller.Kind)
+	assert.Equal(t, ShootAndMoveStrategy, pilot.Controller.Strategy)
+	assert.Equal(t, AutopilotArchetype, pilot.Controller.Archetype)
+	human, _ = e.Entity(human.ID)
+	assert.Equal(t, BotArchetype{}, human.Controller.Archetype, "The players flown by a human shouldn't have archetype")
+}
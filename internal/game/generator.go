@@ -0,0 +1,339 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	// minGeneratedSize is the smallest width and height of a generated map
+	minGeneratedSize = 9
+	// generatorAttempts is how many maps are generated from the same seed
+	// before giving up, the maps with too few open tiles are discarded
+	generatorAttempts = 20
+	// minOpenArea is the part of the map that should be reachable from the
+	// player start
+	minOpenArea = 0.25
+	// caveWallChance is the chance of each tile to start as a wall on the caves
+	caveWallChance = 0.45
+	// caveSteps is how many times the cellular automata smooths the caves
+	caveSteps = 4
+	// startClearance is the distance from the player start kept free of walls
+	// and spawns, the local players start one next to the other
+	startClearance = 2
+	// playerSpacing is the distance between the player spawns of the
+	// generated maps
+	playerSpacing = 2
+)
+
+// MapStyle defines the shape of the generated maps
+type MapStyle int
+
+const (
+	// MapStyleRooms generates rectangular rooms joined by corridors
+	MapStyleRooms MapStyle = iota
+	// MapStyleCaves generates organic caves smoothing random noise
+	MapStyleCaves
+)
+
+// MapStyles keeps all the map styles
+var MapStyles = []MapStyle{MapStyleRooms, MapStyleCaves}
+
+// String returns the name of the map style
+func (s MapStyle) String() string {
+	if s == MapStyleCaves {
+		return "caves"
+	}
+	return "rooms"
+}
+
+// ParseMapStyle returns the map style with the given name, the name is not
+// case sensitive
+func ParseMapStyle(name string) (MapStyle, error) {
+	for _, style := range MapStyles {
+		if strings.EqualFold(style.String(), name) {
+			return style, nil
+		}
+	}
+	return MapStyleRooms, fmt.Errorf("Unknown map style %s", name)
+}
+
+// MapSpec keeps how the generated map should be
+type MapSpec struct {
+	Style MapStyle
+	// Seed is the source of the randomness, the same spec always generates the
+	// same map
+	Seed   int64
+	Width  int
+	Height int
+	// Spawns is how many bot spawn positions the map has
+	Spawns int
+	// Players is how many numbered player spawns the map has, they are placed
+	// one next to the other from the player start to the right
+	Players int
+}
+
+// GenerateMap returns a new map following the given spec, the border of the
+// map is always a wall and every spawn position can be reached from the player
+// start, which is the center of the map
+func GenerateMap(spec MapSpec) (Map, error) {
+	if spec.Width < minGeneratedSize || spec.Height < minGeneratedSize {
+		return nil, fmt.Errorf("The generated maps should be at least %dx%d", minGeneratedSize, minGeneratedSize)
+	}
+	if lastPlayerSpawn(spec.Players) > spec.Width-2-spec.Width/2 {
+		return nil, fmt.Errorf("The generated map of %dx%d has no room for %d players", spec.Width, spec.Height, spec.Players)
+	}
+	rng := rand.New(rand.NewSource(spec.Seed))
+	for attempt := 0; attempt < generatorAttempts; attempt++ {
+		var m Map
+		if spec.Style == MapStyleCaves {
+			m = generateCaves(rng, spec.Width, spec.Height)
+		} else {
+			m = generateRooms(rng, spec.Width, spec.Height)
+		}
+		m.clearStart(spec.Players)
+		m.sealBorder()
+		open := m.fillUnreachable()
+		if float64(open) < minOpenArea*float64((spec.Width-2)*(spec.Height-2)) {
+			continue
+		}
+		m.placePlayerSpawns(spec.Players)
+		if m.placeSpawns(rng, spec.Spawns, spec.Players) {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("Can't generate a %s map of %dx%d with %d spawns", spec.Style, spec.Width, spec.Height, spec.Spawns)
+}
+
+// filledMap returns a map of the given size full of walls
+func filledMap(width int, height int) Map {
+	m := make(Map, height)
+	for y := range m {
+		m[y] = []rune(strings.Repeat("█", width))
+	}
+	return m
+}
+
+// room is a rectangle of open tiles, the position is the top left corner on
+// the map matrix
+type room struct {
+	x, y, width, height int
+}
+
+// center returns the tile on the middle of the room
+func (r room) center() (int, int) {
+	return r.x + r.width/2, r.y + r.height/2
+}
+
+// overlaps returns true when the rooms share any tile or they are touching
+func (r room) overlaps(r2 room) bool {
+	return r.x <= r2.x+r2.width && r2.x <= r.x+r.width && r.y <= r2.y+r2.height && r2.y <= r.y+r.height
+}
+
+// generateRooms will carve random rooms on a map full of walls, each room is
+// joined to the previous one by a corridor so all of them are connected. The
+// first room is always on the center of the map
+func generateRooms(rng *rand.Rand, width int, height int) Map {
+	m := filledMap(width, height)
+	first := room{x: width/2 - 3, y: height/2 - 2, width: 7, height: 5}
+	m.carveRoom(first)
+	rooms := []room{first}
+	for i := 0; i < width*height/40; i++ {
+		r := room{width: 3 + rng.Intn(width/4+1), height: 3 + rng.Intn(height/4+1)}
+		if r.width > width-2 || r.height > height-2 {
+			continue
+		}
+		r.x = 1 + rng.Intn(width-r.width-1)
+		r.y = 1 + rng.Intn(height-r.height-1)
+		overlaps := false
+		for _, other := range rooms {
+			if r.overlaps(other) {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		m.carveRoom(r)
+		m.carveCorridor(rng, rooms[len(rooms)-1], r)
+		rooms = append(rooms, r)
+	}
+	return m
+}
+
+// carveRoom will open all the tiles of the given room
+func (m Map) carveRoom(r room) {
+	for y := r.y; y < r.y+r.height; y++ {
+		for x := r.x; x < r.x+r.width; x++ {
+			if y > 0 && y < len(m)-1 && x > 0 && x < len(m[y])-1 {
+				m[y][x] = ' '
+			}
+		}
+	}
+}
+
+// carveCorridor will open an L shaped corridor between the centers of the
+// given rooms, the corridor starts horizontal or vertical at random
+func (m Map) carveCorridor(rng *rand.Rand, from room, to room) {
+	fromX, fromY := from.center()
+	toX, toY := to.center()
+	cornerX, cornerY := toX, fromY
+	if rng.Intn(2) == 0 {
+		cornerX, cornerY = fromX, toY
+	}
+	m.carveLine(fromX, fromY, cornerX, cornerY)
+	m.carveLine(cornerX, cornerY, toX, toY)
+}
+
+// carveLine will open the tiles of a horizontal or vertical line
+func (m Map) carveLine(fromX int, fromY int, toX int, toY int) {
+	stepX, stepY := sign(toX-fromX), sign(toY-fromY)
+	for x, y := fromX, fromY; ; x, y = x+stepX, y+stepY {
+		m[y][x] = ' '
+		if x == toX && y == toY {
+			return
+		}
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// generateCaves will fill a map with random walls and then it will smooth
+// them, a tile becomes a wall when most of his neighbours are walls and it
+// becomes open when most of them are open
+func generateCaves(rng *rand.Rand, width int, height int) Map {
+	m := filledMap(width, height)
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			if rng.Float64() >= caveWallChance {
+				m[y][x] = ' '
+			}
+		}
+	}
+	for step := 0; step < caveSteps; step++ {
+		smoothed := m.Copy()
+		for y := 1; y < height-1; y++ {
+			for x := 1; x < width-1; x++ {
+				switch walls := m.wallsAround(x, y); {
+				case walls >= 5:
+					smoothed[y][x] = '█'
+				case walls <= 3:
+					smoothed[y][x] = ' '
+				}
+			}
+		}
+		m = smoothed
+	}
+	return m
+}
+
+// wallsAround returns how many of the eight neighbours of the given tile are
+// walls, the tiles out of the map count as walls
+func (m Map) wallsAround(x int, y int) (walls int) {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			ny, nx := y+dy, x+dx
+			if ny < 0 || ny >= len(m) || nx < 0 || nx >= len(m[ny]) || m[ny][nx] == '█' {
+				walls++
+			}
+		}
+	}
+	return walls
+}
+
+// lastPlayerSpawn returns the distance from the player start to the last
+// player spawn on the right
+func lastPlayerSpawn(players int) int {
+	if players < 1 {
+		return 0
+	}
+	return (players - 1) * playerSpacing
+}
+
+// clearStart will open the tiles around the player start and the spawns of
+// the given players
+func (m Map) clearStart(players int) {
+	for y := -startClearance / 2; y <= startClearance/2; y++ {
+		for x := -startClearance; x <= lastPlayerSpawn(players)+startClearance; x++ {
+			if mapX, mapY, ok := m.toMapIndex(Point{X: x, Y: y}); ok {
+				m[mapY][mapX] = ' '
+			}
+		}
+	}
+}
+
+// sealBorder will place a wall on each tile of the border
+func (m Map) sealBorder() {
+	for y := range m {
+		for x := range m[y] {
+			if y == 0 || y == len(m)-1 || x == 0 || x == len(m[y])-1 {
+				m[y][x] = '█'
+			}
+		}
+	}
+}
+
+// fillUnreachable will place a wall on every open tile that can't be reached
+// from the player start, returns how many open tiles are left
+func (m Map) fillUnreachable() (open int) {
+	reachable := m.reachableFrom(Point{})
+	for _, position := range m.GetMapElements()[MapElementNone] {
+		if reachable[position] {
+			open++
+			continue
+		}
+		x, y, _ := m.toMapIndex(position)
+		m[y][x] = '█'
+	}
+	return open
+}
+
+// placePlayerSpawns will number the spawns of the given players from the
+// player start to the right, the tiles are already open by clearStart
+func (m Map) placePlayerSpawns(players int) {
+	for i := 0; i < players; i++ {
+		if x, y, ok := m.toMapIndex(Point{X: i * playerSpacing}); ok {
+			m[y][x] = rune('1' + i)
+		}
+	}
+}
+
+// placeSpawns will place the given amount of spawn positions on random open
+// tiles away from the player start and the spawns of the given players,
+// returns false if there is no room for all of them
+func (m Map) placeSpawns(rng *rand.Rand, spawns int, players int) bool {
+	var candidates []Point
+	for _, position := range m.GetMapElements()[MapElementNone] {
+		if position.X < -startClearance-1 || position.X > lastPlayerSpawn(players)+startClearance+1 || abs(position.Y) > startClearance+1 {
+			candidates = append(candidates, position)
+		}
+	}
+	if len(candidates) < spawns {
+		return false
+	}
+	for _, i := range rng.Perm(len(candidates))[:spawns] {
+		x, y, _ := m.toMapIndex(candidates[i])
+		m[y][x] = 'S'
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
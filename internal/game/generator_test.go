@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMap(t *testing.T) {
+	for _, style := range MapStyles {
+		for seed := int64(0); seed < 20; seed++ {
+			spec := MapSpec{Style: style, Seed: seed, Width: 40, Height: 20, Spawns: 8, Players: 4}
+			t.Run(fmt.Sprintf("%s seed %d", style, seed), func(t *testing.T) {
+				m, err := GenerateMap(spec)
+				assert.Nil(t, err)
+				assert.Len(t, m, spec.Height)
+				for y, row := range m {
+					assert.Len(t, row, spec.Width)
+					for x, r := range row {
+						if y == 0 || y == spec.Height-1 || x == 0 || x == spec.Width-1 {
+							assert.Equal(t, '█', r, "The border should be sealed")
+						}
+					}
+				}
+				// Every open tile and spawn is reachable from the player start
+				reachable := m.reachableFrom(Point{})
+				elements := m.GetMapElements()
+				assert.Len(t, elements[MapElementSpawn], spec.Spawns)
+				assert.Equal(t, []Point{{X: 0}, {X: 2}, {X: 4}, {X: 6}}, m.PlayerSpawns(), "Each player should start on his own spawn")
+				for _, position := range append(append(elements[MapElementSpawn], elements[MapElementNone]...), elements[MapElementPlayerSpawn]...) {
+					assert.True(t, reachable[position], "The position %v is not reachable", position)
+				}
+
+				again, err := GenerateMap(spec)
+				assert.Nil(t, err)
+				assert.Equal(t, m, again, "The same seed should generate the same map")
+			})
+		}
+	}
+}
+
+func TestGenerateMapSeeds(t *testing.T) {
+	first, err := GenerateMap(MapSpec{Style: MapStyleCaves, Seed: 1, Width: 40, Height: 20, Spawns: 4})
+	assert.Nil(t, err)
+	second, err := GenerateMap(MapSpec{Style: MapStyleCaves, Seed: 2, Width: 40, Height: 20, Spawns: 4})
+	assert.Nil(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestGenerateMapErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     MapSpec
+		expected error
+	}{
+		{
+			name:     "Should fail for a map too small",
+			spec:     MapSpec{Width: 5, Height: 20, Spawns: 1},
+			expected: fmt.Errorf("The generated maps should be at least 9x9"),
+		},
+		{
+			name:     "Should fail when the spawns don't fit",
+			spec:     MapSpec{Style: MapStyleCaves, Width: 9, Height: 9, Spawns: 100},
+			expected: fmt.Errorf("Can't generate a caves map of 9x9 with 100 spawns"),
+		},
+		{
+			name:     "Should fail when the player spawns don't fit",
+			spec:     MapSpec{Width: 9, Height: 9, Spawns: 1, Players: 3},
+			expected: fmt.Errorf("The generated map of 9x9 has no room for 3 players"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateMap(tt.spec)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestParseMapStyle(t *testing.T) {
+	style, err := ParseMapStyle("Caves")
+	assert.Nil(t, err)
+	assert.Equal(t, MapStyleCaves, style)
+	_, err = ParseMapStyle("dungeon")
+	assert.Equal(t, fmt.Errorf("Unknown map style dungeon"), err)
+}
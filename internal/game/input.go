@@ -0,0 +1,201 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// DefaultMaxMoveAge is how old a movement can be before being discarded
+const DefaultMaxMoveAge = 150 * time.Millisecond
+
+// DropPolicy decides which action is dropped when the action channel is full
+type DropPolicy int
+
+const (
+	// DropNewest discards the action that doesn't fit on the channel
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest action waiting on the channel for make
+	// room to the new one
+	DropOldest
+)
+
+// DropPolicies keeps all the drop policies
+var DropPolicies = []DropPolicy{DropNewest, DropOldest}
+
+// String returns the name of the drop policy
+func (p DropPolicy) String() string {
+	if p == DropOldest {
+		return "oldest"
+	}
+	return "newest"
+}
+
+// ParseDropPolicy returns the drop policy with the given name, the name is not
+// case sensitive
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	for _, policy := range DropPolicies {
+		if strings.EqualFold(policy.String(), name) {
+			return policy, nil
+		}
+	}
+	return DropNewest, fmt.Errorf("Unknown drop policy %s", name)
+}
+
+// InputPolicy defines how the engine deals with more actions than it can
+// perform
+type InputPolicy struct {
+	Drop DropPolicy
+	// MaxMoveAge is how old a movement can be when is performed, the older
+	// ones are discarded, zero means the movements never get old
+	MaxMoveAge time.Duration
+}
+
+// DefaultInputPolicy drops the new actions and discards the movements older
+// than DefaultMaxMoveAge
+var DefaultInputPolicy = InputPolicy{Drop: DropNewest, MaxMoveAge: DefaultMaxMoveAge}
+
+// InputCounters keeps how many actions never were performed
+type InputCounters struct {
+	// Dropped is how many actions didn't fit on the action channel
+	Dropped int
+	// Coalesced is how many movements were replaced by a newer one of the same
+	// entity before being performed
+	Coalesced int
+	// Stale is how many movements were too old when their turn arrived
+	Stale int
+}
+
+// inputQueue keeps the last movement of each entity waiting to be performed
+// and the counters of the actions discarded
+type inputQueue struct {
+	mutex    sync.Mutex
+	moves    map[uuid.UUID]*MoveAction
+	counters InputCounters
+}
+
+// SetInputPolicy will attach the given input policy to the game engine
+func SetInputPolicy(policy InputPolicy) engineOpt {
+	return func(e *Engine) error {
+		e.InputPolicy = policy
+		return nil
+	}
+}
+
+// Submit will send the given action to the engine without blocking, when the
+// action channel is full an action is dropped following the drop policy. It
+// returns false when the given action is dropped
+func (e *Engine) Submit(action Action) bool {
+	select {
+	case e.ActionChan <- action:
+		return true
+	default:
+	}
+	if e.InputPolicy.Drop == DropOldest {
+		select {
+		case oldest := <-e.ActionChan:
+			e.dropAction(oldest)
+		default:
+		}
+		select {
+		case e.ActionChan <- action:
+			return true
+		default:
+		}
+	}
+	e.dropAction(action)
+	return false
+}
+
+// SubmitMove will send the given movement to the engine without blocking,
+// there is at most one movement of each entity waiting, so a new movement
+// replaces the one is waiting. It returns false when the movement is dropped
+// or rejected for his direction
+func (e *Engine) SubmitMove(m *MoveAction) bool {
+	if !m.Direction.valid() {
+		e.reject(m, ErrInvalidDirection)
+		return false
+	}
+	e.input.mutex.Lock()
+	if e.input.moves == nil {
+		e.input.moves = make(map[uuid.UUID]*MoveAction)
+	}
+	_, waiting := e.input.moves[m.EntityID]
+	e.input.moves[m.EntityID] = m
+	if waiting {
+		e.input.counters.Coalesced++
+	}
+	e.input.mutex.Unlock()
+	if waiting {
+		return true
+	}
+	return e.Submit(&coalescedMoveAction{EntityID: m.EntityID})
+}
+
+// InputCounters returns how many actions were discarded
+func (e *Engine) InputCounters() InputCounters {
+	e.input.mutex.Lock()
+	defer e.input.mutex.Unlock()
+	return e.input.counters
+}
+
+// dropAction will count the given action as dropped and will discard it
+func (e *Engine) dropAction(action Action) {
+	e.input.mutex.Lock()
+	e.input.counters.Dropped++
+	e.input.mutex.Unlock()
+	e.discard(action)
+}
+
+// discard will clean up everything linked to an action that is never
+// performed, as the laser of a laser action
+func (e *Engine) discard(action Action) {
+	if d, ok := action.(Discarder); ok {
+		d.Discard(e)
+	}
+}
+
+// takeMove returns the movement of the given entity waiting to be performed,
+// nil if there is none, and removes it from the queue
+func (e *Engine) takeMove(entityID uuid.UUID) *MoveAction {
+	e.input.mutex.Lock()
+	defer e.input.mutex.Unlock()
+	m := e.input.moves[entityID]
+	delete(e.input.moves, entityID)
+	return m
+}
+
+// coalescedMoveAction is the place of an entity movement on the action channel,
+// once performed it takes the last movement submitted by the entity
+type coalescedMoveAction struct {
+	EntityID uuid.UUID
+}
+
+// Validate will check the entity can move, the movement is taken once the
+// action is performed
+func (c *coalescedMoveAction) Validate(e *Engine) error {
+	return e.validateEntity(c.EntityID)
+}
+
+// Discard will remove the movement of the entity waiting to be performed
+func (c *coalescedMoveAction) Discard(e *Engine) {
+	e.takeMove(c.EntityID)
+}
+
+// Perform will execute the last movement of the entity unless it is too old
+func (c *coalescedMoveAction) Perform(e *Engine) {
+	m := e.takeMove(c.EntityID)
+	if m == nil {
+		return
+	}
+	if e.InputPolicy.MaxMoveAge > 0 && time.Since(m.CreatedAt) > e.InputPolicy.MaxMoveAge {
+		e.input.mutex.Lock()
+		e.input.counters.Stale++
+		e.input.mutex.Unlock()
+		return
+	}
+	m.Perform(e)
+}
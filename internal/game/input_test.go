@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   DropPolicy
+		queued   int
+		dropped  int
+		accepted bool
+	}{
+		{
+			name:     "Should drop the new action",
+			policy:   DropNewest,
+			queued:   0,
+			dropped:  1,
+			accepted: false,
+		},
+		{
+			name:     "Should drop the oldest action",
+			policy:   DropOldest,
+			queued:   1,
+			dropped:  0,
+			accepted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{
+				ActionChan:  make(chan Action, 1),
+				InputPolicy: InputPolicy{Drop: tt.policy},
+			}
+			entityIDs := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+			assert.True(t, e.SubmitMove(&MoveAction{EntityID: entityIDs[0], Direction: DirectionUp}))
+			assert.Equal(t, tt.accepted, e.SubmitMove(&MoveAction{EntityID: entityIDs[1], Direction: DirectionUp}))
+
+			// The movements of the dropped actions are removed
+			queued := (<-e.ActionChan).(*coalescedMoveAction)
+			assert.Equal(t, entityIDs[tt.queued], queued.EntityID)
+			assert.Nil(t, e.takeMove(entityIDs[tt.dropped]))
+			assert.NotNil(t, e.takeMove(entityIDs[tt.queued]))
+			assert.Equal(t, 1, e.InputCounters().Dropped)
+		})
+	}
+}
+
+func TestSubmitMove(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Health: Health{Life: 1}}),
+	)
+	assert.Nil(t, err)
+	position := func() Point {
+		player, _ := e.Entity(playerID)
+		return player.Position
+	}
+	assert.True(t, e.SubmitMove(&MoveAction{EntityID: playerID, Direction: DirectionLeft, CreatedAt: time.Now()}))
+	assert.True(t, e.SubmitMove(&MoveAction{EntityID: playerID, Direction: DirectionRight, CreatedAt: time.Now()}))
+	assert.Len(t, e.ActionChan, 1)
+	assert.Equal(t, 1, e.InputCounters().Coalesced)
+
+	// Only the last movement is performed
+	(<-e.ActionChan).Perform(e)
+	assert.Equal(t, Point{X: 1, Y: 0}, position())
+
+	// The old movements are discarded
+	assert.True(t, e.SubmitMove(&MoveAction{EntityID: playerID, Direction: DirectionRight, CreatedAt: time.Now().Add(-time.Second)}))
+	(<-e.ActionChan).Perform(e)
+	assert.Equal(t, Point{X: 1, Y: 0}, position())
+	assert.Equal(t, 1, e.InputCounters().Stale)
+
+	// The movements without direction are rejected before waiting
+	assert.False(t, e.SubmitMove(&MoveAction{EntityID: playerID, Direction: DirectionNone, CreatedAt: time.Now()}))
+	assert.Len(t, e.ActionChan, 0)
+	assert.Equal(t, map[error]int{ErrInvalidDirection: 1}, e.Rejected())
+}
+
+func TestParseDropPolicy(t *testing.T) {
+	policy, err := ParseDropPolicy("Oldest")
+	assert.Nil(t, err)
+	assert.Equal(t, DropOldest, policy)
+	_, err = ParseDropPolicy("random")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,66 @@
+package game
+
+import "github.com/gofrs/uuid"
+
+// JoinAction adds a player to an engine already started, as the remote players
+// joining a shared match. The player gets the defaults of SetPlayers and starts
+// on the first player spawn not taken by other player
+type JoinAction struct {
+	Player Entity
+}
+
+// Validate will check there is no entity with the same id
+func (j *JoinAction) Validate(e *Engine) error {
+	if _, exists := e.Entity(j.Player.ID); exists {
+		return ErrEntityExists
+	}
+	return nil
+}
+
+// Perform will place the player on a free spawn and will add him to the engine
+func (j *JoinAction) Perform(e *Engine) {
+	player := j.Player
+	player.Position = e.freePlayerSpawn(player.Position)
+	e.storeEntity(preparePlayer(player))
+}
+
+// freePlayerSpawn returns the first player spawn of the map without a player
+// respawning on it. When all of them are taken the players share them, and
+// without player spawns the given position is kept
+func (e *Engine) freePlayerSpawn(position Point) Point {
+	spawns := e.GameMap.PlayerSpawns()
+	if len(spawns) == 0 {
+		return position
+	}
+	players := e.Players()
+	taken := make(map[Point]bool, len(players))
+	for _, player := range players {
+		taken[player.Health.SpawnPosition] = true
+	}
+	for _, spawn := range spawns {
+		if !taken[spawn] {
+			return spawn
+		}
+	}
+	return spawns[len(players)%len(spawns)]
+}
+
+// LeaveAction removes a player from the engine, as a remote player closing his
+// connection
+type LeaveAction struct {
+	EntityID uuid.UUID
+}
+
+// Validate will check the player exists
+func (l *LeaveAction) Validate(e *Engine) error {
+	if !e.isPlayer(l.EntityID) {
+		return ErrUnknownEntity
+	}
+	return nil
+}
+
+// Perform will remove the player from the engine, his score and stats are kept
+// for the summary of the match
+func (l *LeaveAction) Perform(e *Engine) {
+	e.removeEntity(l.EntityID)
+}
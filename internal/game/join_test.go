@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinAction(t *testing.T) {
+	spawnsMap := Map{
+		[]rune("█████"),
+		[]rune("█2  █"),
+		[]rune("█   █"),
+		[]rune("█  1█"),
+		[]rune("█████"),
+	}
+	tests := []struct {
+		name      string
+		gameMap   Map
+		players   int
+		positions []Point
+	}{
+		{
+			name:      "Should place each player on a free spawn",
+			gameMap:   spawnsMap,
+			players:   2,
+			positions: []Point{{X: 1, Y: 1}, {X: -1, Y: -1}},
+		},
+		{
+			name:      "Should share the spawns when all of them are taken",
+			gameMap:   spawnsMap,
+			players:   3,
+			positions: []Point{{X: 1, Y: 1}, {X: -1, Y: -1}, {X: 1, Y: 1}},
+		},
+		{
+			name:      "Should keep the position on the maps without player spawns",
+			gameMap:   mapTest1,
+			players:   1,
+			positions: []Point{{X: 2, Y: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEngine(SetMap(tt.gameMap))
+			assert.Nil(t, err)
+			for i := 0; i < tt.players; i++ {
+				player := NewPlayer(fmt.Sprintf("Player %d", i+1), DifficultyNormal)
+				player.Position = Point{X: 2, Y: 0}
+				assert.Nil(t, e.Perform(&JoinAction{Player: player}))
+				joined, exists := e.Entity(player.ID)
+				assert.True(t, exists)
+				assert.Equal(t, tt.positions[i], joined.Position)
+				assert.Equal(t, tt.positions[i], joined.Health.SpawnPosition)
+				assert.Equal(t, DefaultWeapon, joined.Weapon)
+				assert.Equal(t, TeamPlayers, joined.Team)
+				assert.Equal(t, ErrEntityExists, e.Perform(&JoinAction{Player: player}))
+			}
+		})
+	}
+}
+
+func TestLeaveAction(t *testing.T) {
+	player := NewPlayer("Player 1", DifficultyNormal)
+	bot := Entity{ID: uuid.Must(uuid.NewV4()), Position: Point{X: 2, Y: 0}, Controller: Controller{Kind: ControllerBot}}
+	e, err := NewEngine(SetMap(mapTest1), SetPlayers(player))
+	assert.Nil(t, err)
+	e.storeEntity(bot)
+
+	assert.Nil(t, e.Perform(&LeaveAction{EntityID: player.ID}))
+	_, exists := e.Entity(player.ID)
+	assert.False(t, exists)
+	assert.Equal(t, ErrUnknownEntity, e.Perform(&LeaveAction{EntityID: player.ID}))
+	assert.Equal(t, ErrUnknownEntity, e.Perform(&LeaveAction{EntityID: bot.ID}), "Only the players can leave")
+}
@@ -1,64 +1,165 @@
 package game
 
-import "github.com/gofrs/uuid"
+import (
+	"time"
 
-// Origin is used to define from where the action is coming from
-type Origin int
-
-const (
-	// OriginUnknown define a non known origin
-	OriginUnknown Origin = iota
-	// OriginPlayer is applied when the owner of the action is a player
-	OriginPlayer
-	// OriginBot is applied when the own of the action is a bot
-	OriginBot
+	"github.com/gofrs/uuid"
 )
 
 // Laser defines a laser shoot
 type Laser struct {
 	ID       uuid.UUID
 	Position Point
-	Origin   Origin
+	// ShooterID keeps the id of the entity who shoot the laser
+	ShooterID uuid.UUID
+	// Team keeps the faction of the shooter
+	Team Team
+	// Damage is how many hit points the laser takes from his target, the
+	// lasers without damage take one
+	Damage int
+	// Speed is how long the laser needs for move from one position to the
+	// next, the lasers without speed move as the default weapon
+	Speed time.Duration
 }
 
-// checkCollisions will check if there is some bot on the given laser position
-// if it is will reduce the life and remove the bot if needed and return true,
-// otherwise do nothing and return false
-func (e *Engine) checkLaserCollisions(laserPosition Point, origin Origin) (collide bool) {
-	// TODO refacor this, each actor should has his own check collider
-	switch origin {
-	case OriginPlayer:
-		botToDelete := uuid.Nil
-		e.Bots.Range(func(key interface{}, value interface{}) bool {
-			bot := value.(Bot)
-			botID := key.(uuid.UUID)
-			if bot.Position.Equal(laserPosition) {
-				bot.Life--
-				e.Bots.Store(botID, bot)
-				if bot.Life == 0 {
-					botToDelete = botID
-				}
-				collide = true
-				return false
+// launchLaser will move the given laser on the given direction until it
+// collides with something or the engine stops, each laser is moved only by the
+// fire action that creates it
+func (e *Engine) launchLaser(laser Laser, direction Direction) {
+	ticker := time.NewTicker(laser.speed())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.done:
+			e.Lasers.Delete(laser.ID)
+			return
+		case <-ticker.C:
+			if e.IsPaused() {
+				continue
 			}
-			return true
-		})
-		// We can't remove a key value on a ranging map
-		if botToDelete != uuid.Nil {
-			e.Bots.Delete(botToDelete)
-			e.LevelComplete = e.botCount() == 0
-		}
-	case OriginBot:
-		for actorID, actor := range e.Actors {
-			if actor.Position.Equal(laserPosition) {
-				collide = true
-				actor.Life--
-				e.Actors[actorID] = actor
-				if actor.Life <= 0 && !e.GameOver {
-					e.GameOver = true
-				}
+			var gone bool
+			if laser, gone = e.moveLaser(laser, direction); gone {
+				return
 			}
 		}
 	}
-	return collide
+}
+
+// moveLaser will move the given laser one position on the given direction and
+// will check his collisions with the walls and the ships, it returns the laser
+// moved and true once the laser is gone. The lasers move on their own
+// goroutines so the engine is locked as it is while performing an action
+func (e *Engine) moveLaser(laser Laser, direction Direction) (Laser, bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	switch direction {
+	case DirectionUp:
+		laser.Position.Y--
+	case DirectionDown:
+		laser.Position.Y++
+	case DirectionRight:
+		laser.Position.X++
+	case DirectionLeft:
+		laser.Position.X--
+	}
+	// Check collisions with wall and also for other ships, the destructible
+	// walls will be eroded by the laser
+	if e.GameMap.IsWall(laser.Position) {
+		e.GameMap.damageWall(laser.Position)
+		e.Lasers.Delete(laser.ID)
+		return laser, true
+	}
+	if e.checkLaserCollisions(laser) {
+		e.Lasers.Delete(laser.ID)
+		e.updateScores(laser.ShooterID)
+		return laser, true
+	}
+	// Update position to be printed
+	e.Lasers.Store(laser.ID, laser)
+	return laser, false
+}
+
+// checkLaserCollisions will check if there is some enemy of the shooter on the
+// given laser position, if it is will reduce his life and return true,
+// otherwise do nothing and return false
+func (e *Engine) checkLaserCollisions(laser Laser) (collide bool) {
+	hit := uuid.Nil
+	e.RangeEntities(func(entity Entity) bool {
+		if !entity.Health.Eliminated && entity.Occupies(laser.Position) && e.isHostile(laser.ShooterID, laser.Team, entity.ID, entity.Team) {
+			hit = entity.ID
+			return false
+		}
+		return true
+	})
+	if hit == uuid.Nil {
+		return false
+	}
+	e.recordHit(laser.ShooterID)
+	e.damageEntity(hit, laser.ShooterID, laser.damage())
+	return true
+}
+
+// damage returns the hit points taken by the laser
+func (l Laser) damage() int {
+	if l.Damage <= 0 {
+		return 1
+	}
+	return l.Damage
+}
+
+// speed returns the time the laser needs for move one position
+func (l Laser) speed() time.Duration {
+	if l.Speed <= 0 {
+		return DefaultWeapon.Speed
+	}
+	return l.Speed
+}
+
+// damageEntity will reduce the life of the given entity, when the entity runs
+// out of life he will respawn if there are lives left. Otherwise the bots are
+// removed giving his score value to the attacker and the players are
+// eliminated. The campaign level is completed once there are no bots and the
+// game is over once all the players are eliminated. The attacker is uuid.Nil
+// when the damage doesn't come from a laser
+func (e *Engine) damageEntity(entityID uuid.UUID, attackerID uuid.UUID, damage int) {
+	entity, exists := e.Entity(entityID)
+	now := time.Now()
+	if !exists || entity.Health.Eliminated || entity.IsInvulnerable(now) {
+		return
+	}
+	entity.Health.Life -= damage
+	e.recordDamage(entityID, damage)
+	if entity.Health.Life > 0 {
+		e.storeEntity(entity)
+		return
+	}
+	e.addFrag(attackerID, entity.Team)
+	e.recordKill(attackerID, entity.killKind())
+	if e.isPlayer(attackerID) {
+		e.Score[attackerID] += entity.Controller.Archetype.ScoreValue
+	}
+	entity.Health.Lives--
+	if entity.Health.Lives > 0 {
+		entity.respawn(now)
+		e.storeEntity(entity)
+		return
+	}
+	if !entity.IsPlayer() {
+		e.removeEntity(entityID)
+		if e.Rules.Mode == ModeCampaign && e.botCount() == 0 {
+			e.RoundWinner = e.decideRoundWinner()
+			e.FinishedAt = now
+			e.LevelComplete = true
+		}
+		return
+	}
+	entity.Health.Eliminated = true
+	e.storeEntity(entity)
+	if !e.GameOver && e.allPlayersEliminated() {
+		e.RoundWinner = e.decideRoundWinner()
+		e.FinishedAt = now
+		e.GameOver = true
+		return
+	}
+	e.checkLastStanding()
 }
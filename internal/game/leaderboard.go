@@ -0,0 +1,197 @@
+package game
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MaxHighScores is how many high scores are kept for each board
+const MaxHighScores = 10
+
+// HighScore is a record on the leaderboard, the boards are split by map, mode
+// and difficulty
+type HighScore struct {
+	Map        string        `json:"map"`
+	Mode       string        `json:"mode"`
+	Difficulty string        `json:"difficulty"`
+	Name       string        `json:"name"`
+	Score      int           `json:"score"`
+	Duration   time.Duration `json:"duration"`
+	Date       time.Time     `json:"date"`
+}
+
+// Board identifies one of the leaderboards
+type Board struct {
+	Map        string
+	Mode       string
+	Difficulty string
+}
+
+// Board returns the board where the high score belongs
+func (h HighScore) Board() Board {
+	return Board{Map: h.Map, Mode: h.Mode, Difficulty: h.Difficulty}
+}
+
+// Leaderboard keeps the high scores stored on a JSON file
+type Leaderboard struct {
+	path   string
+	mutex  sync.Mutex
+	scores []HighScore
+}
+
+// LoadLeaderboard will read the high scores from the file on the given path,
+// if the file doesn't exist yet the leaderboard starts empty
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	l := &Leaderboard{path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.scores); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Save will write the high scores on the leaderboard file, the file is
+// replaced once the new one is completely written
+func (l *Leaderboard) Save() error {
+	l.mutex.Lock()
+	data, err := json.MarshalIndent(l.scores, "", "  ")
+	l.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
+
+// Top returns the high scores of the given board from the best to the worst
+// one, the ties are for the fastest and then for the first one
+func (l *Leaderboard) Top(board Board) []HighScore {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.top(board)
+}
+
+func (l *Leaderboard) top(board Board) (top []HighScore) {
+	for _, score := range l.scores {
+		if score.Board() == board {
+			top = append(top, score)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Score != top[j].Score {
+			return top[i].Score > top[j].Score
+		}
+		if top[i].Duration != top[j].Duration {
+			return top[i].Duration < top[j].Duration
+		}
+		return top[i].Date.Before(top[j].Date)
+	})
+	return top
+}
+
+// Qualifies returns true when the given score enters on the top of the given
+// board
+func (l *Leaderboard) Qualifies(board Board, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	top := l.Top(board)
+	return len(top) < MaxHighScores || score > top[len(top)-1].Score
+}
+
+// Add will record the given high score and will return his position on the
+// board, starting from zero, the board only keeps the best MaxHighScores so
+// the position is -1 when the score doesn't enter on the board
+func (l *Leaderboard) Add(highScore HighScore) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	board := highScore.Board()
+	l.scores = append(l.scores, highScore)
+	top := l.top(board)
+	kept := l.scores[:0]
+	for _, score := range l.scores {
+		if score.Board() != board {
+			kept = append(kept, score)
+		}
+	}
+	if len(top) > MaxHighScores {
+		top = top[:MaxHighScores]
+	}
+	l.scores = append(kept, top...)
+	for position, score := range top {
+		if score == highScore {
+			return position
+		}
+	}
+	return -1
+}
+
+// Boards returns all the boards with at least one high score, sorted by map,
+// mode and difficulty
+func (l *Leaderboard) Boards() (boards []Board) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	seen := make(map[Board]bool)
+	for _, score := range l.scores {
+		if !seen[score.Board()] {
+			seen[score.Board()] = true
+			boards = append(boards, score.Board())
+		}
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		if boards[i].Map != boards[j].Map {
+			return boards[i].Map < boards[j].Map
+		}
+		if boards[i].Mode != boards[j].Mode {
+			return boards[i].Mode < boards[j].Mode
+		}
+		return boards[i].Difficulty < boards[j].Difficulty
+	})
+	return boards
+}
+
+// Board returns the leaderboard where the rounds of the engine are recorded
+func (e *Engine) Board() Board {
+	return Board{
+		Map:        e.LevelName,
+		Mode:       e.Rules.Mode.String(),
+		Difficulty: e.Difficulty.String(),
+	}
+}
+
+// NewHighScore returns the high score of the given player with the time the
+// match lasted, the name is the player name
+func (e *Engine) NewHighScore(playerID uuid.UUID) HighScore {
+	board := e.Board()
+	player, _ := e.Entity(playerID)
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return HighScore{
+		Map:        board.Map,
+		Mode:       board.Mode,
+		Difficulty: board.Difficulty,
+		Name:       player.Name,
+		Score:      e.Score[playerID],
+		Duration:   e.elapsed(),
+		Date:       time.Now(),
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardAdd(t *testing.T) {
+	board := Board{Map: "Outpost", Mode: "Campaign", Difficulty: "Normal"}
+	other := Board{Map: "Outpost", Mode: "Campaign", Difficulty: "Hard"}
+	score := func(board Board, name string, points int, duration time.Duration) HighScore {
+		return HighScore{
+			Map:        board.Map,
+			Mode:       board.Mode,
+			Difficulty: board.Difficulty,
+			Name:       name,
+			Score:      points,
+			Duration:   duration,
+		}
+	}
+	l := &Leaderboard{}
+	assert.Equal(t, 0, l.Add(score(board, "Ramon", 100, time.Minute)))
+	assert.Equal(t, 0, l.Add(score(board, "Marta", 200, time.Minute)))
+	assert.Equal(t, 1, l.Add(score(board, "Pau", 100, 30*time.Second)))
+	assert.Equal(t, 0, l.Add(score(other, "Ramon", 50, time.Minute)))
+
+	top := l.Top(board)
+	assert.Len(t, top, 3)
+	assert.Equal(t, []string{"Marta", "Pau", "Ramon"}, []string{top[0].Name, top[1].Name, top[2].Name})
+	assert.Len(t, l.Top(other), 1)
+	assert.Equal(t, []Board{other, board}, l.Boards())
+
+	for i := 0; i < MaxHighScores; i++ {
+		l.Add(score(board, "Bot", 1000, time.Minute))
+	}
+	assert.Len(t, l.Top(board), MaxHighScores)
+	assert.False(t, l.Qualifies(board, 500))
+	assert.True(t, l.Qualifies(board, 1500))
+	assert.True(t, l.Qualifies(other, 1))
+	assert.False(t, l.Qualifies(other, 0))
+	assert.Equal(t, -1, l.Add(score(board, "Ramon", 10, time.Minute)))
+	assert.Len(t, l.Top(other), 1)
+}
+
+func TestLeaderboardSaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "leaderboard")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "scores", "scores.json")
+
+	l, err := LoadLeaderboard(path)
+	assert.Nil(t, err)
+	assert.Empty(t, l.Boards())
+
+	date := time.Date(2020, 4, 14, 10, 0, 0, 0, time.UTC)
+	highScore := HighScore{
+		Map:        "Outpost",
+		Mode:       "Survival",
+		Difficulty: "Easy",
+		Name:       "Ramon",
+		Score:      300,
+		Duration:   90 * time.Second,
+		Date:       date,
+	}
+	l.Add(highScore)
+	assert.Nil(t, l.Save())
+
+	loaded, err := LoadLeaderboard(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []HighScore{highScore}, loaded.Top(highScore.Board()))
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("not json"), 0644))
+	_, err = LoadLeaderboard(path)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,26 @@
+package game
+
+// Level keeps all the information needed for build a round of the game
+type Level struct {
+	Name string
+	Map  Map
+	// Bots keeps the bot for each spawn position of the map, in the same order
+	// the spawns appear on the map
+	Bots []BotSpawn
+}
+
+// SetLevel will attach the map and the bots defined on the given level to the
+// game engine, on the survival mode the bots come from the waves so the level
+// bots are ignored, this means the rules need to be set before the level
+func SetLevel(l Level) engineOpt {
+	return func(e *Engine) error {
+		if err := SetMap(l.Map)(e); err != nil {
+			return err
+		}
+		e.LevelName = l.Name
+		if e.Rules.Mode == ModeSurvival {
+			return nil
+		}
+		return SetBotSpawns(l.Bots)(e)
+	}
+}
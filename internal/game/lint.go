@@ -0,0 +1,174 @@
+package game
+
+import "fmt"
+
+// LintKind defines the kind of problem found on a map
+type LintKind int
+
+const (
+	// LintEmptyMap is a map without tiles
+	LintEmptyMap LintKind = iota
+	// LintRaggedRow is a row with a different width than the first one, the
+	// map center and so all the positions are wrong with ragged rows
+	LintRaggedRow
+	// LintOpenBorder is a tile of the border that isn't a solid wall, the
+	// lasers crossing it fly forever
+	LintOpenBorder
+	// LintPlayerOnWall is a player start where the ship can't move
+	LintPlayerOnWall
+	// LintUnreachableSpawn is a spawn position the players can't reach
+	LintUnreachableSpawn
+	// LintSpawnCount is a map with a different amount of spawn positions than
+	// bots on his level
+	LintSpawnCount
+	// LintDuplicatePlayerSpawn is a player spawn number used more than once,
+	// only the first one is used
+	LintDuplicatePlayerSpawn
+)
+
+// String returns the name of the lint kind
+func (k LintKind) String() string {
+	switch k {
+	case LintEmptyMap:
+		return "empty map"
+	case LintRaggedRow:
+		return "ragged row"
+	case LintOpenBorder:
+		return "open border"
+	case LintPlayerOnWall:
+		return "player on wall"
+	case LintUnreachableSpawn:
+		return "unreachable spawn"
+	case LintSpawnCount:
+		return "spawn count"
+	case LintDuplicatePlayerSpawn:
+		return "duplicate player spawn"
+	}
+	return "unknown"
+}
+
+// LintIssue is a problem found on a map, the row and the column are the
+// position on the map matrix starting from zero, the same as the map files.
+// The empty maps and the spawn count issues are about the whole map so they
+// have no position
+type LintIssue struct {
+	Kind    LintKind
+	Row     int
+	Column  int
+	Message string
+}
+
+// String returns the issue with his position, if any
+func (i LintIssue) String() string {
+	if i.Kind == LintEmptyMap || i.Kind == LintSpawnCount {
+		return fmt.Sprintf("%s: %s", i.Kind, i.Message)
+	}
+	return fmt.Sprintf("row %d, column %d: %s: %s", i.Row, i.Column, i.Kind, i.Message)
+}
+
+// Lint returns all the problems found on the map, the ragged rows, the border
+// tiles that aren't solid walls, the player start on a wall, the repeated
+// player spawns and the spawn positions that can't be reached from the player
+// start. The player start is the first player spawn or the center of the map
+// when there isn't any
+func (m Map) Lint() (issues []LintIssue) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return []LintIssue{{Kind: LintEmptyMap, Message: "the map has no tiles"}}
+	}
+	width := len(m[0])
+	for y, row := range m {
+		if len(row) != width {
+			issues = append(issues, LintIssue{
+				Kind:    LintRaggedRow,
+				Row:     y,
+				Column:  len(row),
+				Message: fmt.Sprintf("the row has %d tiles instead of %d", len(row), width),
+			})
+		}
+	}
+	for y, row := range m {
+		for x, r := range row {
+			border := y == 0 || y == len(m)-1 || x == 0 || x == len(row)-1
+			if border && mapElementFromRune(r) != MapElementWall {
+				issues = append(issues, LintIssue{
+					Kind:    LintOpenBorder,
+					Row:     y,
+					Column:  x,
+					Message: fmt.Sprintf("the border tile %q is not a solid wall", r),
+				})
+			}
+		}
+	}
+	center := m.getMapCenter()
+	start := Point{}
+	playerSpawns := m.PlayerSpawns()
+	if len(playerSpawns) > 0 {
+		start = playerSpawns[0]
+	}
+	if m.IsBlocking(start) {
+		issues = append(issues, LintIssue{
+			Kind:    LintPlayerOnWall,
+			Row:     start.Y + center.Y,
+			Column:  start.X + center.X,
+			Message: fmt.Sprintf("the player starts on %q", m.RuneAt(start)),
+		})
+	}
+	numbers := make(map[rune]bool)
+	for _, spawn := range playerSpawns {
+		number := m.RuneAt(spawn)
+		if numbers[number] {
+			issues = append(issues, LintIssue{
+				Kind:    LintDuplicatePlayerSpawn,
+				Row:     spawn.Y + center.Y,
+				Column:  spawn.X + center.X,
+				Message: fmt.Sprintf("the player spawn %q is repeated", number),
+			})
+		}
+		numbers[number] = true
+	}
+	// The one way tiles make the way back different, so each player spawn
+	// needs to reach the player start as well
+	reachable := m.reachableFrom(start)
+	for _, spawn := range playerSpawns {
+		switch {
+		case !reachable[spawn]:
+			issues = append(issues, LintIssue{
+				Kind:    LintUnreachableSpawn,
+				Row:     spawn.Y + center.Y,
+				Column:  spawn.X + center.X,
+				Message: fmt.Sprintf("the player spawn %q can't be reached from the player start", m.RuneAt(spawn)),
+			})
+		case !m.reachableFrom(spawn)[start]:
+			issues = append(issues, LintIssue{
+				Kind:    LintUnreachableSpawn,
+				Row:     spawn.Y + center.Y,
+				Column:  spawn.X + center.X,
+				Message: fmt.Sprintf("the player start can't be reached from the player spawn %q", m.RuneAt(spawn)),
+			})
+		}
+	}
+	for _, spawn := range m.GetMapElements()[MapElementSpawn] {
+		if !reachable[spawn] {
+			issues = append(issues, LintIssue{
+				Kind:    LintUnreachableSpawn,
+				Row:     spawn.Y + center.Y,
+				Column:  spawn.X + center.X,
+				Message: "the spawn can't be reached from the player start",
+			})
+		}
+	}
+	return issues
+}
+
+// Lint returns all the problems found on the map of the level and the spawn
+// positions without bot or the bots without spawn position
+func (l Level) Lint() []LintIssue {
+	issues := l.Map.Lint()
+	if spawns := len(l.Map.GetMapElements()[MapElementSpawn]); spawns != len(l.Bots) {
+		issues = append(issues, LintIssue{
+			Kind:    LintSpawnCount,
+			Message: fmt.Sprintf("the map has %d spawn positions for %d bots", spawns, len(l.Bots)),
+		})
+	}
+	return issues
+}
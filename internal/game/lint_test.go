@@ -0,0 +1,124 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameMap  Map
+		expected []LintIssue
+	}{
+		{
+			name:    "Shouldn't find issues on a valid map",
+			gameMap: mapTest1,
+		},
+		{
+			name:     "Should find an empty map",
+			gameMap:  Map{},
+			expected: []LintIssue{{Kind: LintEmptyMap, Message: "the map has no tiles"}},
+		},
+		{
+			name: "Should find the ragged rows and their open border",
+			gameMap: Map{
+				[]rune("█████"),
+				[]rune("█   █"),
+				[]rune("█  "),
+				[]rune("█████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintRaggedRow, Row: 2, Column: 3, Message: "the row has 3 tiles instead of 5"},
+				{Kind: LintOpenBorder, Row: 2, Column: 2, Message: "the border tile ' ' is not a solid wall"},
+			},
+		},
+		{
+			name: "Should find the border tiles that aren't solid walls",
+			gameMap: Map{
+				[]rune("██#██"),
+				[]rune("▓   █"),
+				[]rune("█████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintOpenBorder, Row: 0, Column: 2, Message: "the border tile '#' is not a solid wall"},
+				{Kind: LintOpenBorder, Row: 1, Column: 0, Message: "the border tile '▓' is not a solid wall"},
+			},
+		},
+		{
+			name: "Should find the player starting on a wall and the spawns sealed off",
+			gameMap: Map{
+				[]rune("███████"),
+				[]rune("█S█  S█"),
+				[]rune("███#  █"),
+				[]rune("█ ▓█  █"),
+				[]rune("███████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintPlayerOnWall, Row: 2, Column: 3, Message: "the player starts on '#'"},
+				{Kind: LintUnreachableSpawn, Row: 1, Column: 1, Message: "the spawn can't be reached from the player start"},
+			},
+		},
+		{
+			name: "Should start from the first player spawn and find the repeated ones",
+			gameMap: Map{
+				[]rune("███████"),
+				[]rune("█1 █ S█"),
+				[]rune("█  █ 2█"),
+				[]rune("█ 1█  █"),
+				[]rune("███████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintDuplicatePlayerSpawn, Row: 3, Column: 2, Message: "the player spawn '1' is repeated"},
+				{Kind: LintUnreachableSpawn, Row: 2, Column: 5, Message: "the player spawn '2' can't be reached from the player start"},
+				{Kind: LintUnreachableSpawn, Row: 1, Column: 5, Message: "the spawn can't be reached from the player start"},
+			},
+		},
+		{
+			name: "Should follow the one way tiles",
+			gameMap: Map{
+				[]rune("███████"),
+				[]rune("█S←   █"),
+				[]rune("███ ███"),
+				[]rune("█S→   █"),
+				[]rune("███████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintUnreachableSpawn, Row: 3, Column: 1, Message: "the spawn can't be reached from the player start"},
+			},
+		},
+		{
+			name: "Should find the player spawns without way back to the player start",
+			gameMap: Map{
+				[]rune("███████"),
+				[]rune("█1→ 2 █"),
+				[]rune("███████"),
+			},
+			expected: []LintIssue{
+				{Kind: LintUnreachableSpawn, Row: 1, Column: 4, Message: "the player start can't be reached from the player spawn '2'"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.gameMap.Lint())
+		})
+	}
+}
+
+func TestLintLevel(t *testing.T) {
+	level := Level{Map: mapTest1, Bots: []BotSpawn{{}}}
+	assert.Equal(t, []LintIssue{
+		{Kind: LintSpawnCount, Message: "the map has 2 spawn positions for 1 bots"},
+	}, level.Lint())
+	assert.Equal(t, "spawn count: the map has 2 spawn positions for 1 bots", level.Lint()[0].String())
+	level.Bots = append(level.Bots, BotSpawn{})
+	assert.Empty(t, level.Lint())
+	assert.Equal(t, "row 2, column 3: unreachable spawn: the spawn can't be reached from the player start", LintIssue{
+		Kind:    LintUnreachableSpawn,
+		Row:     2,
+		Column:  3,
+		Message: "the spawn can't be reached from the player start",
+	}.String())
+}
@@ -1,5 +1,7 @@
 package game
 
+import "sort"
+
 // Map is a matrix representation of game map
 type Map [][]rune
 
@@ -13,8 +15,67 @@ const (
 	MapElementWall
 	// MapElementSpawn identifies when there is someone on this point of the map
 	MapElementSpawn
+	// MapElementDestructibleWall identifies a wall that can be destroyed by the
+	// lasers, each hit will erode the wall until it disappears
+	MapElementDestructibleWall
+	// MapElementSlow identifies a tile that slows down the ships over it
+	MapElementSlow
+	// MapElementHazard identifies a tile that damages the ships entering on it
+	MapElementHazard
+	// MapElementOneWay identifies a tile that can only be crossed on the
+	// direction pointed by his arrow
+	MapElementOneWay
+	// MapElementPhaseWall identifies a wall that blocks the ships but lets the
+	// lasers go through it
+	MapElementPhaseWall
+	// MapElementPlayerSpawn identifies where a player starts, the glyph is the
+	// number of the player
+	MapElementPlayerSpawn
 )
 
+// destructibleWallStages keeps the glyphs used for the destructible walls from
+// the strongest to the weakest one, each laser hit moves the wall one stage down
+var destructibleWallStages = []rune{'▓', '▒', '░'}
+
+// mapElementFromRune will translate the given glyph into his map element
+func mapElementFromRune(r rune) MapElement {
+	switch r {
+	case '█':
+		return MapElementWall
+	case 'S':
+		return MapElementSpawn
+	case '▓', '▒', '░':
+		return MapElementDestructibleWall
+	case '~':
+		return MapElementSlow
+	case '*':
+		return MapElementHazard
+	case '↑', '↓', '←', '→':
+		return MapElementOneWay
+	case '#':
+		return MapElementPhaseWall
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return MapElementPlayerSpawn
+	}
+	return MapElementNone
+}
+
+// oneWayDirection returns the only direction allowed for cross the given one
+// way glyph
+func oneWayDirection(r rune) Direction {
+	switch r {
+	case '↑':
+		return DirectionUp
+	case '↓':
+		return DirectionDown
+	case '←':
+		return DirectionLeft
+	case '→':
+		return DirectionRight
+	}
+	return DirectionNone
+}
+
 // GetMapElements goes through the game map, and return a description of each
 // map element and his position taking as origin the center point on the map
 func (m Map) GetMapElements() map[MapElement][]Point {
@@ -22,13 +83,7 @@ func (m Map) GetMapElements() map[MapElement][]Point {
 	elements := make(map[MapElement][]Point, 0)
 	for mapY, row := range m {
 		for mapX, col := range row {
-			mapElement := MapElementNone
-			switch col {
-			case '█':
-				mapElement = MapElementWall
-			case 'S':
-				mapElement = MapElementSpawn
-			}
+			mapElement := mapElementFromRune(col)
 			elements[mapElement] = append(elements[mapElement], Point{
 				X: mapX - center.X,
 				Y: mapY - center.Y,
@@ -38,24 +93,150 @@ func (m Map) GetMapElements() map[MapElement][]Point {
 	return elements
 }
 
-// SetMap will attach the given map to the game engine
+// PlayerSpawns returns the positions where the players start sorted by his
+// number, the first player starts on the first one
+func (m Map) PlayerSpawns() []Point {
+	spawns := m.GetMapElements()[MapElementPlayerSpawn]
+	sort.SliceStable(spawns, func(i, j int) bool {
+		return m.RuneAt(spawns[i]) < m.RuneAt(spawns[j])
+	})
+	return spawns
+}
+
+// SetMap will attach a copy of the given map to the game engine, we copy it
+// because some tiles as the destructible walls change during the game
 func SetMap(m Map) engineOpt {
 	return func(e *Engine) error {
-		e.GameMap = m
+		e.GameMap = m.Copy()
 		return nil
 	}
 }
 
-// IsWall will check if on the given position exists a wall
+// Map returns a copy of the game map, the destructible walls change while the
+// game is running so outside of the actions the map should be read from here
+func (e *Engine) Map() Map {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.GameMap.Copy()
+}
+
+// Copy returns a deep copy of the map
+func (m Map) Copy() Map {
+	c := make(Map, len(m))
+	for y, row := range m {
+		c[y] = make([]rune, len(row))
+		copy(c[y], row)
+	}
+	return c
+}
+
+// RuneAt returns the glyph placed on the given position, positions out of the
+// map are considered empty space
+func (m Map) RuneAt(p Point) rune {
+	x, y, ok := m.toMapIndex(p)
+	if !ok {
+		return ' '
+	}
+	return m[y][x]
+}
+
+// ElementAt returns the map element placed on the given position
+func (m Map) ElementAt(p Point) MapElement {
+	return mapElementFromRune(m.RuneAt(p))
+}
+
+// IsWall will check if on the given position exists a wall, the destructible
+// walls are walls as well until they are destroyed
 func (m Map) IsWall(p Point) bool {
-	for _, position := range m.GetMapElements()[MapElementWall] {
-		if position.Equal(p) {
+	element := m.ElementAt(p)
+	return element == MapElementWall || element == MapElementDestructibleWall
+}
+
+// IsBlocking will check if a ship can't stand on the given position, as the
+// walls of any kind
+func (m Map) IsBlocking(p Point) bool {
+	switch m.ElementAt(p) {
+	case MapElementWall, MapElementDestructibleWall, MapElementPhaseWall:
+		return true
+	}
+	return false
+}
+
+// CanMove will check if a ship moving on the given direction is allowed to
+// enter on the given position
+func (m Map) CanMove(p Point, d Direction) bool {
+	switch m.ElementAt(p) {
+	case MapElementWall, MapElementDestructibleWall, MapElementPhaseWall:
+		return false
+	case MapElementOneWay:
+		return oneWayDirection(m.RuneAt(p)) == d
+	}
+	return true
+}
+
+// damageWall will erode the destructible wall placed on the given position,
+// returns true if the wall was destroyed with this hit
+func (m Map) damageWall(p Point) (destroyed bool) {
+	x, y, ok := m.toMapIndex(p)
+	if !ok || mapElementFromRune(m[y][x]) != MapElementDestructibleWall {
+		return false
+	}
+	for stage, r := range destructibleWallStages {
+		if r != m[y][x] {
+			continue
+		}
+		if stage == len(destructibleWallStages)-1 {
+			m[y][x] = ' '
 			return true
 		}
+		m[y][x] = destructibleWallStages[stage+1]
+		return false
 	}
 	return false
 }
 
+// reachableFrom returns all the positions a ship can reach moving from the
+// given position, the walls and the one way tiles are taken into account. The
+// destructible walls don't block the way since the lasers can destroy them
+func (m Map) reachableFrom(start Point) map[Point]bool {
+	reachable := map[Point]bool{start: true}
+	pending := []Point{start}
+	directions := map[Direction]Point{
+		DirectionUp:    {X: 0, Y: -1},
+		DirectionDown:  {X: 0, Y: 1},
+		DirectionLeft:  {X: -1, Y: 0},
+		DirectionRight: {X: 1, Y: 0},
+	}
+	for len(pending) > 0 {
+		position := pending[0]
+		pending = pending[1:]
+		for direction, offset := range directions {
+			next := position.Add(offset)
+			if _, _, ok := m.toMapIndex(next); !ok || reachable[next] {
+				continue
+			}
+			if !m.CanMove(next, direction) && m.ElementAt(next) != MapElementDestructibleWall {
+				continue
+			}
+			reachable[next] = true
+			pending = append(pending, next)
+		}
+	}
+	return reachable
+}
+
+// toMapIndex will translate the given position, which has the center of the
+// map as origin, into the row and column of the map matrix
+func (m Map) toMapIndex(p Point) (x int, y int, ok bool) {
+	center := m.getMapCenter()
+	x = p.X + center.X
+	y = p.Y + center.Y
+	if y < 0 || y >= len(m) || x < 0 || x >= len(m[y]) {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
 // getMapDimensions will get the dimensions of the current map, in the form
 // width + height
 func (m Map) getMapDimensions() (int, int) {
@@ -182,3 +182,82 @@ func TestIsWallMethod(t *testing.T) {
 		})
 	}
 }
+
+var mapTestTiles = [][]rune{
+	{'█', '█', '█', '█', '█'},
+	{'█', '▓', '~', '*', '█'},
+	{'█', '→', ' ', '#', '█'},
+	{'█', '░', 'S', '↑', '█'},
+	{'█', '█', '█', '█', '█'},
+}
+
+func TestElementAtMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		position Point
+		expected MapElement
+	}{
+		{name: "Should find a wall", position: Point{X: -2, Y: -2}, expected: MapElementWall},
+		{name: "Should find a destructible wall", position: Point{X: -1, Y: -1}, expected: MapElementDestructibleWall},
+		{name: "Should find a slow tile", position: Point{X: 0, Y: -1}, expected: MapElementSlow},
+		{name: "Should find a hazard tile", position: Point{X: 1, Y: -1}, expected: MapElementHazard},
+		{name: "Should find a one way tile", position: Point{X: -1, Y: 0}, expected: MapElementOneWay},
+		{name: "Should find a phase wall", position: Point{X: 1, Y: 0}, expected: MapElementPhaseWall},
+		{name: "Should find a spawn", position: Point{X: 0, Y: 1}, expected: MapElementSpawn},
+		{name: "Should find nothing", position: Point{X: 0, Y: 0}, expected: MapElementNone},
+		{name: "Should find nothing [out of the map]", position: Point{X: 10, Y: 10}, expected: MapElementNone},
+	}
+	gameMap := Map(mapTestTiles)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gameMap.ElementAt(tt.position))
+		})
+	}
+}
+
+func TestCanMoveMethod(t *testing.T) {
+	tests := []struct {
+		name      string
+		position  Point
+		direction Direction
+		expected  bool
+	}{
+		{name: "Shouldn't enter on a destructible wall", position: Point{X: -1, Y: -1}, direction: DirectionLeft, expected: false},
+		{name: "Shouldn't enter on a phase wall", position: Point{X: 1, Y: 0}, direction: DirectionRight, expected: false},
+		{name: "Should enter on a slow tile", position: Point{X: 0, Y: -1}, direction: DirectionUp, expected: true},
+		{name: "Should enter on a one way tile following his direction", position: Point{X: -1, Y: 0}, direction: DirectionRight, expected: true},
+		{name: "Shouldn't enter on a one way tile against his direction", position: Point{X: -1, Y: 0}, direction: DirectionLeft, expected: false},
+	}
+	gameMap := Map(mapTestTiles)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gameMap.CanMove(tt.position, tt.direction))
+		})
+	}
+}
+
+func TestDamageWallMethod(t *testing.T) {
+	gameMap := Map(mapTestTiles).Copy()
+	position := Point{X: -1, Y: -1}
+
+	assert.False(t, gameMap.damageWall(position))
+	assert.Equal(t, '▒', gameMap.RuneAt(position))
+	assert.False(t, gameMap.damageWall(position))
+	assert.Equal(t, '░', gameMap.RuneAt(position))
+	assert.True(t, gameMap.damageWall(position))
+	assert.Equal(t, MapElementNone, gameMap.ElementAt(position))
+	assert.False(t, gameMap.damageWall(Point{X: -2, Y: -2}), "Solid walls can't be destroyed")
+	assert.Equal(t, '▓', Map(mapTestTiles).RuneAt(position), "The original map shouldn't change")
+}
+
+func TestPlayerSpawnsMethod(t *testing.T) {
+	gameMap := Map{
+		[]rune("█████"),
+		[]rune("█2 S█"),
+		[]rune("█ 1 █"),
+		[]rune("█3  █"),
+		[]rune("█████"),
+	}
+	assert.Equal(t, []Point{{X: 0, Y: 0}, {X: -1, Y: -1}, {X: -1, Y: 1}}, gameMap.PlayerSpawns())
+	assert.Empty(t, Map(mapTest1).PlayerSpawns())
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseMap will build a map from the given text, each line of the text is a
+// row of the map and each glyph a tile
+func ParseMap(text string) Map {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return Map{}
+	}
+	lines := strings.Split(text, "\n")
+	m := make(Map, len(lines))
+	for y, line := range lines {
+		m[y] = []rune(line)
+	}
+	return m
+}
+
+// String returns the map as text, one line for each row, the same format used
+// on the map files
+func (m Map) String() string {
+	var text strings.Builder
+	for _, row := range m {
+		text.WriteString(string(row))
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// LoadMap will read the map saved on the given file
+func LoadMap(path string) (Map, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := ParseMap(string(data))
+	if len(m) == 0 {
+		return nil, fmt.Errorf("The map file %s is empty", path)
+	}
+	return m, nil
+}
+
+// SaveMap will write the given map on the given file, the directory is created
+// if it doesn't exist
+func SaveMap(path string, m Map) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(m.String()), 0644)
+}
+
+// Rectangle returns a copy of the map with the rows shorter than the widest one
+// filled with empty tiles, so the map is a rectangle. The maps smaller than the
+// given size on any side are rejected, as the blank map files
+func (m Map) Rectangle(minSize int) (Map, error) {
+	width := 0
+	for _, row := range m {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	if width < minSize || len(m) < minSize {
+		return nil, fmt.Errorf("The map of %dx%d is smaller than %dx%d", width, len(m), minSize, minSize)
+	}
+	return m.Resize(width, len(m)), nil
+}
+
+// NewMap returns an empty map of the given size surrounded by walls
+func NewMap(width int, height int) Map {
+	m := make(Map, height)
+	for y := range m {
+		m[y] = make([]rune, width)
+		for x := range m[y] {
+			m[y][x] = ' '
+			if x == 0 || y == 0 || x == width-1 || y == height-1 {
+				m[y][x] = '█'
+			}
+		}
+	}
+	return m
+}
+
+// Resize returns a copy of the map with the given size, the new tiles are empty
+// and the ones out of the new size are lost. The rows shorter than the others
+// are filled as well, so the resized map is always a rectangle
+func (m Map) Resize(width int, height int) Map {
+	resized := make(Map, height)
+	for y := range resized {
+		resized[y] = make([]rune, width)
+		for x := range resized[y] {
+			resized[y][x] = ' '
+			if y < len(m) && x < len(m[y]) {
+				resized[y][x] = m[y][x]
+			}
+		}
+	}
+	return resized
+}
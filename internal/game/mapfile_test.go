@@ -0,0 +1,117 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected Map
+	}{
+		{
+			name:     "Should parse each line as a row",
+			text:     "███\n█S█\n███\n",
+			expected: Map{[]rune("███"), []rune("█S█"), []rune("███")},
+		},
+		{
+			name:     "Should keep the trailing spaces and ignore the windows line endings",
+			text:     "███\r\n█  \r\n███",
+			expected: Map{[]rune("███"), []rune("█  "), []rune("███")},
+		},
+		{
+			name:     "Should return an empty map for an empty text",
+			text:     "",
+			expected: Map{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseMap(tt.text))
+		})
+	}
+}
+
+func TestSaveAndLoadMap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "maps")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "levels", "test.map")
+
+	assert.Nil(t, SaveMap(path, mapTest1))
+	m, err := LoadMap(path)
+	assert.Nil(t, err)
+	assert.Equal(t, Map(mapTest1), m)
+
+	_, err = LoadMap(filepath.Join(dir, "missing.map"))
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "empty.map"), nil, 0644))
+	_, err = LoadMap(filepath.Join(dir, "empty.map"))
+	assert.NotNil(t, err)
+}
+
+func TestNewMap(t *testing.T) {
+	assert.Equal(t, Map{
+		[]rune("████"),
+		[]rune("█  █"),
+		[]rune("████"),
+	}, NewMap(4, 3))
+}
+
+func TestResizeMap(t *testing.T) {
+	m := Map{
+		[]rune("███"),
+		[]rune("█S"),
+		[]rune("███"),
+	}
+	assert.Equal(t, Map{
+		[]rune("███ "),
+		[]rune("█S  "),
+		[]rune("███ "),
+		[]rune("    "),
+	}, m.Resize(4, 4))
+	assert.Equal(t, Map{
+		[]rune("██"),
+		[]rune("█S"),
+	}, m.Resize(2, 2))
+	assert.Equal(t, []rune("█S"), m[1], "The original map shouldn't change")
+}
+
+func TestRectangleMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        Map
+		expected Map
+		err      error
+	}{
+		{
+			name:     "Should fill the ragged rows",
+			m:        Map{[]rune("███"), []rune("█S"), []rune("███")},
+			expected: Map{[]rune("███"), []rune("█S "), []rune("███")},
+		},
+		{
+			name: "Should reject a map of blank lines",
+			m:    ParseMap("\n\n\n"),
+			err:  fmt.Errorf("The map of 0x3 is smaller than 3x3"),
+		},
+		{
+			name: "Should reject a map too short",
+			m:    Map{[]rune("███"), []rune("███")},
+			err:  fmt.Errorf("The map of 3x2 is smaller than 3x3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := tt.m.Rectangle(3)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
@@ -10,3 +10,11 @@ type Point struct {
 func (p Point) Equal(p2 Point) bool {
 	return p.X == p2.X && p.Y == p2.Y
 }
+
+// Add returns the position resulting of move p by the offset p2
+func (p Point) Add(p2 Point) Point {
+	return Point{
+		X: p.X + p2.X,
+		Y: p.Y + p2.Y,
+	}
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// playerKills is the key used on the kills for the players killed
+const playerKills = "player"
+
+// PlayerStats keeps how a player performed during the match
+type PlayerStats struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Score       int       `json:"score"`
+	ShotsFired  int       `json:"shotsFired"`
+	Hits        int       `json:"hits"`
+	Accuracy    float64   `json:"accuracy"`
+	DamageTaken int       `json:"damageTaken"`
+	// Kills keeps how many enemies the player killed by bot strategy, the
+	// other players are kept as player
+	Kills map[string]int `json:"kills"`
+	// Distance is how many positions the player moved
+	Distance int `json:"distance"`
+}
+
+// MatchStats keeps the stats of all the players on a match
+type MatchStats struct {
+	Level      string        `json:"level"`
+	Mode       string        `json:"mode"`
+	Difficulty string        `json:"difficulty"`
+	Duration   time.Duration `json:"duration"`
+	// TimeToClear is how long the players needed for complete the level, it
+	// is zero while the level is not complete
+	TimeToClear time.Duration `json:"timeToClear,omitempty"`
+	Players     []PlayerStats `json:"players"`
+}
+
+// TotalKills returns how many enemies the player killed
+func (s PlayerStats) TotalKills() (kills int) {
+	for _, count := range s.Kills {
+		kills += count
+	}
+	return kills
+}
+
+// playerStats returns the stats of the given player, nil if the given id is
+// not a player. The stats change from the actions and the lasers, so it should
+// be called with the engine locked
+func (e *Engine) playerStats(playerID uuid.UUID) *PlayerStats {
+	if !e.isPlayer(playerID) {
+		return nil
+	}
+	if e.Stats == nil {
+		e.Stats = make(map[uuid.UUID]*PlayerStats)
+	}
+	stats, exists := e.Stats[playerID]
+	if !exists {
+		stats = &PlayerStats{ID: playerID, Kills: make(map[string]int)}
+		e.Stats[playerID] = stats
+	}
+	return stats
+}
+
+// recordShot will count a laser shot by the given shooter
+func (e *Engine) recordShot(shooterID uuid.UUID) {
+	if stats := e.playerStats(shooterID); stats != nil {
+		stats.ShotsFired++
+	}
+}
+
+// recordHit will count a laser from the given shooter hitting an enemy
+func (e *Engine) recordHit(shooterID uuid.UUID) {
+	if stats := e.playerStats(shooterID); stats != nil {
+		stats.Hits++
+	}
+}
+
+// recordDamage will count the damage taken by the given player
+func (e *Engine) recordDamage(playerID uuid.UUID, damage int) {
+	if stats := e.playerStats(playerID); stats != nil {
+		stats.DamageTaken += damage
+	}
+}
+
+// recordKill will count an enemy killed by the given killer, the kind is the
+// bot strategy or player for the players
+func (e *Engine) recordKill(killerID uuid.UUID, kind string) {
+	if stats := e.playerStats(killerID); stats != nil {
+		stats.Kills[kind]++
+	}
+}
+
+// recordMove will count a position moved by the given player
+func (e *Engine) recordMove(playerID uuid.UUID) {
+	if stats := e.playerStats(playerID); stats != nil {
+		stats.Distance++
+	}
+}
+
+// MatchStats returns the stats of all the players on the current match, the
+// players are sorted as the score ranking
+func (e *Engine) MatchStats() MatchStats {
+	board := e.Board()
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	match := MatchStats{
+		Level:      board.Map,
+		Mode:       board.Mode,
+		Difficulty: board.Difficulty,
+		Duration:   e.elapsed(),
+	}
+	if e.LevelComplete {
+		match.TimeToClear = match.Duration
+	}
+	for _, player := range e.Players() {
+		stats := PlayerStats{ID: player.ID, Kills: make(map[string]int)}
+		if recorded, exists := e.Stats[player.ID]; exists {
+			stats = *recorded
+			stats.Kills = make(map[string]int)
+			for kind, count := range recorded.Kills {
+				stats.Kills[kind] = count
+			}
+		}
+		stats.Name = player.Name
+		stats.Score = e.Score[player.ID]
+		if stats.ShotsFired > 0 {
+			stats.Accuracy = float64(stats.Hits) / float64(stats.ShotsFired)
+		}
+		match.Players = append(match.Players, stats)
+	}
+	sort.Slice(match.Players, func(i, j int) bool {
+		if match.Players[i].Score == match.Players[j].Score {
+			return match.Players[i].Name < match.Players[j].Name
+		}
+		return match.Players[i].Score > match.Players[j].Score
+	})
+	return match
+}
+
+// elapsed returns how long the match lasted, until now if the match is not
+// finished yet
+func (e *Engine) elapsed() time.Duration {
+	if e.FinishedAt.IsZero() {
+		return time.Since(e.StartedAt).Round(time.Second)
+	}
+	return e.FinishedAt.Sub(e.StartedAt).Round(time.Second)
+}
+
+// WriteMatchStats will write the given stats as JSON on the file on the given
+// path
+func WriteMatchStats(path string, stats MatchStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchStats(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Name: "Ramon", Health: Health{Life: 3, Lives: 1}}),
+		SetBotSpawns([]BotSpawn{
+			{Strategy: OnlyShootingStrategy, Archetype: "turret"},
+			{Strategy: ShootAndMoveStrategy, Archetype: "scout"},
+		}),
+	)
+	assert.Nil(t, err)
+	e.StartedAt = time.Now().Add(-time.Minute)
+	var bots []Entity
+	e.RangeEntities(func(entity Entity) bool {
+		if !entity.IsPlayer() {
+			bots = append(bots, entity)
+		}
+		return true
+	})
+
+	// Three shots, the first misses and the others kill the scout
+	for i := 0; i < 3; i++ {
+		e.recordShot(playerID)
+	}
+	for _, bot := range bots {
+		if bot.Controller.Archetype.Name == "scout" {
+			assert.True(t, e.checkLaserCollisions(Laser{Position: bot.Position, ShooterID: playerID, Team: TeamPlayers}))
+			assert.True(t, e.checkLaserCollisions(Laser{Position: bot.Position, ShooterID: playerID, Team: TeamPlayers}))
+		}
+	}
+	e.damageEntity(playerID, bots[0].ID, 2)
+	(&MoveAction{EntityID: playerID, Direction: DirectionRight, CreatedAt: time.Now()}).Perform(e)
+
+	stats := e.MatchStats()
+	assert.Equal(t, time.Minute, stats.Duration)
+	assert.Zero(t, stats.TimeToClear)
+	assert.Len(t, stats.Players, 1)
+	player := stats.Players[0]
+	assert.Equal(t, "Ramon", player.Name)
+	assert.Equal(t, 3, player.ShotsFired)
+	assert.Equal(t, 2, player.Hits)
+	assert.InDelta(t, 0.66, player.Accuracy, 0.01)
+	assert.Equal(t, map[string]int{"shoot and move": 1}, player.Kills)
+	assert.Equal(t, 2, player.DamageTaken)
+	assert.Equal(t, 1, player.Distance)
+
+	// The stats are a copy of the ones kept by the engine
+	player.Kills["boss"] = 1
+	assert.Equal(t, 1, e.MatchStats().Players[0].TotalKills())
+
+	e.LevelComplete = true
+	e.FinishedAt = e.StartedAt.Add(30 * time.Second)
+	stats = e.MatchStats()
+	assert.Equal(t, 30*time.Second, stats.TimeToClear)
+
+	dir, err := ioutil.TempDir("", "stats")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, WriteMatchStats(filepath.Join(dir, "stats.json"), stats))
+	data, err := ioutil.ReadFile(filepath.Join(dir, "stats.json"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"shoot and move": 1`)
+}
+
+func TestConcurrentHits(t *testing.T) {
+	gameMap := Map{
+		[]rune("█████████"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█       █"),
+		[]rune("█████████"),
+	}
+	shooters := map[Direction]Entity{
+		DirectionRight: {ID: uuid.Must(uuid.NewV4()), Position: Point{X: -2}},
+		DirectionLeft:  {ID: uuid.Must(uuid.NewV4()), Position: Point{X: 2}},
+		DirectionDown:  {ID: uuid.Must(uuid.NewV4()), Position: Point{Y: -2}},
+		DirectionUp:    {ID: uuid.Must(uuid.NewV4()), Position: Point{Y: 2}},
+	}
+	var players []Entity
+	for _, shooter := range shooters {
+		shooter.Health = Health{Life: 3}
+		players = append(players, shooter)
+	}
+	e, err := NewEngine(SetMap(gameMap), SetPlayers(players...))
+	assert.Nil(t, err)
+	defer e.Stop()
+	targetID := uuid.Must(uuid.NewV4())
+	e.storeEntity(Entity{
+		ID:         targetID,
+		Health:     Health{Life: 10, MaxLife: 10, Lives: 1},
+		Team:       TeamBots,
+		Controller: Controller{Kind: ControllerBot},
+	})
+
+	// All the lasers land on the target at the same time while the match is
+	// read as the user interfaces do
+	now := time.Now()
+	for direction, shooter := range shooters {
+		go e.Perform(&FireAction{ShooterID: shooter.ID, Direction: direction, CreatedAt: now})
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e.MatchStats()
+		e.Round()
+		e.Map()
+		target, _ := e.Entity(targetID)
+		if target.Health.Life == 10-len(shooters)*DefaultWeapon.Damage {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("The lasers never hit the target")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stats := e.MatchStats()
+	assert.Len(t, stats.Players, len(shooters))
+	for _, player := range stats.Players {
+		assert.Equal(t, 1, player.ShotsFired)
+		assert.Equal(t, 1, player.Hits)
+	}
+	score := e.Round().Score
+	assert.Len(t, score, len(shooters))
+	for _, score := range score {
+		assert.Equal(t, 10, score)
+	}
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// survivalTick is how often the survival loop checks the current wave
+	survivalTick = 100 * time.Millisecond
+	// waveClearedDelay is the break between a cleared wave and the next one
+	waveClearedDelay = 2 * time.Second
+)
+
+// Wave keeps the bots spawned on a survival wave and how long the players have
+// for clear it before the next one comes
+type Wave struct {
+	Bots     []BotSpawn
+	Duration time.Duration
+}
+
+// WaveSchedule returns the wave for the given number, the first wave is the
+// number one
+type WaveSchedule func(number int) Wave
+
+// DefaultWaveSchedule adds one bot more on each wave, the first waves are only
+// moving grunts and each wave has more shooting ones, the shooting only bots
+// are turrets, from the third wave about half of the moving bots are scouts and
+// every fourth wave comes with a tank. The time between waves also decreases
+// until a minimum
+func DefaultWaveSchedule(number int) Wave {
+	count := number + 2
+	shootAndMove := number / 2
+	onlyShooting := number / 3
+	if shootAndMove+onlyShooting > count {
+		onlyShooting = count - shootAndMove
+	}
+	bots := make([]BotSpawn, 0, count+1)
+	for i := 0; i < count; i++ {
+		switch {
+		case i < shootAndMove:
+			bots = append(bots, BotSpawn{Strategy: ShootAndMoveStrategy, Archetype: "grunt"})
+		case i < shootAndMove+onlyShooting:
+			bots = append(bots, BotSpawn{Strategy: OnlyShootingStrategy, Archetype: "turret"})
+		case number >= 3 && i%2 == 0:
+			bots = append(bots, BotSpawn{Strategy: OnlyMovementStrategy, Archetype: "scout"})
+		default:
+			bots = append(bots, BotSpawn{Strategy: OnlyMovementStrategy, Archetype: "grunt"})
+		}
+	}
+	if number%4 == 0 {
+		bots = append(bots, BotSpawn{Strategy: ShootAndMoveStrategy, Archetype: "tank"})
+	}
+	duration := 30*time.Second - time.Duration(number)*2*time.Second
+	if duration < 10*time.Second {
+		duration = 10 * time.Second
+	}
+	return Wave{
+		Bots:     bots,
+		Duration: duration,
+	}
+}
+
+// SetWaveSchedule will attach the given wave schedule to the game engine, it
+// is only used on the survival mode
+func SetWaveSchedule(schedule WaveSchedule) engineOpt {
+	return func(e *Engine) error {
+		e.WaveSchedule = schedule
+		return nil
+	}
+}
+
+// spawnWave will add the bots of the given wave to the engine, the bots are
+// placed on the spawn positions one by one, if there are more bots than spawn
+// positions we start again from the first one. The size of the wave is scaled
+// by the difficulty
+func (e *Engine) spawnWave(wave Wave) (bots []Entity, err error) {
+	spawnElements := e.GameMap.GetMapElements()[MapElementSpawn]
+	if len(spawnElements) == 0 {
+		return nil, fmt.Errorf("There are no spawn positions for the wave")
+	}
+	spawns, _ := e.Difficulty.Settings().scaleBots(wave.Bots)
+	for index, spawn := range spawns {
+		bot, err := e.storeBot(spawn, spawnElements[index%len(spawnElements)])
+		if err != nil {
+			return bots, err
+		}
+		bots = append(bots, bot)
+	}
+	return bots, nil
+}
+
+// survivalLoop will spawn the waves of bots until the game is over, the next
+// wave comes when the time of the current one is over or a few seconds after
+// all his bots are destroyed, the time doesn't run while the game is paused
+func (e *Engine) survivalLoop() {
+	schedule := e.WaveSchedule
+	if schedule == nil {
+		schedule = DefaultWaveSchedule
+	}
+	ticker := time.NewTicker(survivalTick)
+	defer ticker.Stop()
+	for {
+		e.mutex.Lock()
+		e.Wave++
+		number := e.Wave
+		wave := schedule(number)
+		bots, err := e.spawnWave(wave)
+		e.mutex.Unlock()
+		if err != nil {
+			log.Println("Error spawning the wave", number, err)
+		}
+		for _, bot := range bots {
+			go bot.Controller.Strategy.perform(e, bot)
+		}
+		var elapsed, cleared time.Duration
+		for elapsed < wave.Duration && cleared < waveClearedDelay {
+			select {
+			case <-e.done:
+				return
+			case <-ticker.C:
+			}
+			if e.Round().GameOver {
+				return
+			}
+			if e.IsPaused() {
+				continue
+			}
+			elapsed += survivalTick
+			if e.botCount() == 0 {
+				cleared += survivalTick
+			}
+		}
+		e.mutex.Lock()
+		e.WavesSurvived = e.Wave
+		e.mutex.Unlock()
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultWaveSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   int
+		expected map[BotSpawn]int
+		duration time.Duration
+	}{
+		{
+			name:     "Should be only moving grunts on the first wave",
+			number:   1,
+			expected: map[BotSpawn]int{{Strategy: OnlyMovementStrategy, Archetype: "grunt"}: 3},
+			duration: 28 * time.Second,
+		},
+		{
+			name:   "Should add shooting bots and scouts on the next waves",
+			number: 6,
+			expected: map[BotSpawn]int{
+				{Strategy: ShootAndMoveStrategy, Archetype: "grunt"}:  3,
+				{Strategy: OnlyShootingStrategy, Archetype: "turret"}: 2,
+				{Strategy: OnlyMovementStrategy, Archetype: "scout"}:  1,
+				{Strategy: OnlyMovementStrategy, Archetype: "grunt"}:  2,
+			},
+			duration: 18 * time.Second,
+		},
+		{
+			name:   "Should add a tank every fourth wave and keep a minimum duration",
+			number: 28,
+			expected: map[BotSpawn]int{
+				{Strategy: ShootAndMoveStrategy, Archetype: "grunt"}:  14,
+				{Strategy: OnlyShootingStrategy, Archetype: "turret"}: 9,
+				{Strategy: OnlyMovementStrategy, Archetype: "scout"}:  3,
+				{Strategy: OnlyMovementStrategy, Archetype: "grunt"}:  4,
+				{Strategy: ShootAndMoveStrategy, Archetype: "tank"}:   1,
+			},
+			duration: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wave := DefaultWaveSchedule(tt.number)
+			bots := make(map[BotSpawn]int)
+			for _, spawn := range wave.Bots {
+				bots[spawn]++
+			}
+			assert.Equal(t, tt.expected, bots)
+			assert.Equal(t, tt.duration, wave.Duration)
+		})
+	}
+}
+
+func TestSpawnWave(t *testing.T) {
+	e := &Engine{GameMap: mapTest}
+	bots, err := e.spawnWave(Wave{
+		Bots: []BotSpawn{
+			{Strategy: OnlyMovementStrategy},
+			{Strategy: OnlyShootingStrategy, Archetype: "turret"},
+			{Strategy: ShootAndMoveStrategy, Archetype: "tank"},
+		},
+	})
+	assert.Nil(t, err)
+	spawnElements := Map(mapTest).GetMapElements()[MapElementSpawn]
+	assert.Equal(t, 3, len(bots))
+	assert.Equal(t, 3, e.botCount())
+	assert.Equal(t, spawnElements[0], bots[0].Position)
+	assert.Equal(t, spawnElements[1], bots[1].Position)
+	assert.Equal(t, spawnElements[0], bots[2].Position)
+	assert.Equal(t, TeamBots, bots[2].Team)
+	assert.Equal(t, 10, bots[2].Health.Life)
+}
+
+func TestSurvivalLoop(t *testing.T) {
+	e, err := NewEngine(
+		SetRules(Rules{Mode: ModeSurvival}),
+		SetMap(mapTest),
+		SetPlayers(Entity{Health: Health{Life: 3}, Position: Point{X: 1, Y: 0}}),
+		SetWaveSchedule(func(number int) Wave {
+			return Wave{Duration: survivalTick}
+		}),
+	)
+	assert.Nil(t, err)
+	e.Start()
+	defer e.Stop()
+
+	// The waves go on while the user interface reads them
+	deadline := time.Now().Add(5 * time.Second)
+	for e.Round().WavesSurvived < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("The waves never came")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	round := e.Round()
+	assert.True(t, round.Wave >= round.WavesSurvived)
+}
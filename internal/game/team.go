@@ -0,0 +1,198 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Team is used to define the faction of each ship, the ships on the same team
+// are allies
+type Team int
+
+const (
+	// TeamNone define a ship without faction
+	TeamNone Team = iota
+	// TeamBots is the faction of all the bots
+	TeamBots
+	// TeamPlayers is the faction of the players on the campaign and the free
+	// for all deathmatch
+	TeamPlayers
+	// TeamRed is one of the factions on the team deathmatch
+	TeamRed
+	// TeamBlue is one of the factions on the team deathmatch
+	TeamBlue
+)
+
+// String returns the name of the team
+func (t Team) String() string {
+	switch t {
+	case TeamBots:
+		return "Bots"
+	case TeamPlayers:
+		return "Players"
+	case TeamRed:
+		return "Red"
+	case TeamBlue:
+		return "Blue"
+	}
+	return "None"
+}
+
+// GameMode defines the rules for win a round
+type GameMode int
+
+const (
+	// ModeCampaign is won once all the bots are destroyed
+	ModeCampaign GameMode = iota
+	// ModeDeathmatch is a free for all, every player is an enemy and the first
+	// one reaching the frag limit wins
+	ModeDeathmatch
+	// ModeTeamDeathmatch is won by the first team reaching the frag limit
+	ModeTeamDeathmatch
+	// ModeSurvival spawns waves of bots until the players die
+	ModeSurvival
+)
+
+// GameModes keeps all the available game modes
+var GameModes = []GameMode{ModeCampaign, ModeDeathmatch, ModeTeamDeathmatch, ModeSurvival}
+
+// ParseGameMode returns the game mode with the given name, the name is not case
+// sensitive and the spaces can be replaced by dashes
+func ParseGameMode(name string) (GameMode, error) {
+	for _, mode := range GameModes {
+		if strings.EqualFold(strings.Replace(mode.String(), " ", "-", -1), strings.Replace(name, " ", "-", -1)) {
+			return mode, nil
+		}
+	}
+	return ModeCampaign, fmt.Errorf("Unknown game mode %s", name)
+}
+
+// String returns the name of the game mode
+func (m GameMode) String() string {
+	switch m {
+	case ModeDeathmatch:
+		return "Deathmatch"
+	case ModeTeamDeathmatch:
+		return "Team deathmatch"
+	case ModeSurvival:
+		return "Survival"
+	}
+	return "Campaign"
+}
+
+// DefaultFragLimit is the frag limit used on the deathmatch modes when there is
+// no one defined
+const DefaultFragLimit = 10
+
+// Rules keeps the configuration about how the round is played
+type Rules struct {
+	Mode GameMode
+	// FriendlyFire determines when the lasers can damage the allies
+	FriendlyFire bool
+	// FragLimit is the number of kills needed for win a deathmatch round
+	FragLimit int
+	// FogOfWar determines when the ships only see what is on their line of
+	// sight, the bots don't aim at the players they can't see
+	FogOfWar bool
+}
+
+// SetRules will attach the given rules to the game engine
+func SetRules(r Rules) engineOpt {
+	return func(e *Engine) error {
+		if r.FragLimit == 0 {
+			r.FragLimit = DefaultFragLimit
+		}
+		e.Rules = r
+		return nil
+	}
+}
+
+// isHostile returns wether a laser shoot by the given shooter and team can
+// damage a target with the given id and team
+func (e *Engine) isHostile(shooterID uuid.UUID, shooterTeam Team, targetID uuid.UUID, targetTeam Team) bool {
+	if shooterID == targetID {
+		return false
+	}
+	if e.Rules.Mode == ModeDeathmatch && shooterTeam != TeamBots && targetTeam != TeamBots {
+		return true
+	}
+	return shooterTeam != targetTeam || e.Rules.FriendlyFire
+}
+
+// addFrag will count a kill for the given player and will finish the round when
+// the frag limit is reached, the kills of the bots and the kills against allies
+// are not counted
+func (e *Engine) addFrag(killerID uuid.UUID, victimTeam Team) {
+	killer, exists := e.Entity(killerID)
+	if !exists || !killer.IsPlayer() || (killer.Team == victimTeam && e.Rules.Mode != ModeDeathmatch) {
+		return
+	}
+	if e.Frags == nil {
+		e.Frags = make(map[uuid.UUID]int)
+	}
+	e.Frags[killerID]++
+	switch e.Rules.Mode {
+	case ModeDeathmatch:
+		if e.Frags[killerID] >= e.Rules.FragLimit {
+			e.finishRound(killerID, TeamNone)
+		}
+	case ModeTeamDeathmatch:
+		if e.teamFrags(killer.Team) >= e.Rules.FragLimit {
+			e.finishRound(e.topFragger(killer.Team), killer.Team)
+		}
+	}
+}
+
+// teamFrags returns the sum of the kills of all the players on the given team
+func (e *Engine) teamFrags(team Team) (frags int) {
+	for _, player := range e.Players() {
+		if player.Team == team {
+			frags += e.Frags[player.ID]
+		}
+	}
+	return frags
+}
+
+// topFragger returns the player with more kills on the given team
+func (e *Engine) topFragger(team Team) (best uuid.UUID) {
+	bestFrags := -1
+	for _, player := range e.Players() {
+		if player.Team == team && e.Frags[player.ID] > bestFrags {
+			best, bestFrags = player.ID, e.Frags[player.ID]
+		}
+	}
+	return best
+}
+
+// checkLastStanding will finish a deathmatch round when only one player, or
+// only one team on the team deathmatch, is not eliminated
+func (e *Engine) checkLastStanding() {
+	if e.Rules.Mode == ModeCampaign || e.LevelComplete {
+		return
+	}
+	var standing []Entity
+	teams := make(map[Team]bool)
+	for _, player := range e.Players() {
+		if !player.Health.Eliminated {
+			standing = append(standing, player)
+			teams[player.Team] = true
+		}
+	}
+	switch {
+	case e.Rules.Mode == ModeDeathmatch && len(standing) == 1:
+		e.finishRound(standing[0].ID, TeamNone)
+	case e.Rules.Mode == ModeTeamDeathmatch && len(teams) == 1:
+		e.finishRound(e.topFragger(standing[0].Team), standing[0].Team)
+	}
+}
+
+// finishRound will complete the level with the given winner
+func (e *Engine) finishRound(winner uuid.UUID, team Team) {
+	e.RoundWinner = winner
+	e.WinningTeam = team
+	e.FinishedAt = time.Now()
+	e.LevelComplete = true
+}
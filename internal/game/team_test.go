@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHostile(t *testing.T) {
+	shooter := uuid.Must(uuid.NewV4())
+	target := uuid.Must(uuid.NewV4())
+	tests := []struct {
+		name       string
+		rules      Rules
+		shooterID  uuid.UUID
+		shooter    Team
+		targetTeam Team
+		expected   bool
+	}{
+		{
+			name:       "Shouldn't damage himself",
+			rules:      Rules{Mode: ModeDeathmatch, FriendlyFire: true},
+			shooterID:  target,
+			shooter:    TeamPlayers,
+			targetTeam: TeamPlayers,
+			expected:   false,
+		},
+		{
+			name:       "Should damage the enemies",
+			rules:      Rules{Mode: ModeCampaign},
+			shooterID:  shooter,
+			shooter:    TeamPlayers,
+			targetTeam: TeamBots,
+			expected:   true,
+		},
+		{
+			name:       "Shouldn't damage the allies without friendly fire",
+			rules:      Rules{Mode: ModeCampaign},
+			shooterID:  shooter,
+			shooter:    TeamPlayers,
+			targetTeam: TeamPlayers,
+			expected:   false,
+		},
+		{
+			name:       "Should damage the allies with friendly fire",
+			rules:      Rules{Mode: ModeTeamDeathmatch, FriendlyFire: true},
+			shooterID:  shooter,
+			shooter:    TeamRed,
+			targetTeam: TeamRed,
+			expected:   true,
+		},
+		{
+			name:       "Should damage every player on a deathmatch",
+			rules:      Rules{Mode: ModeDeathmatch},
+			shooterID:  shooter,
+			shooter:    TeamPlayers,
+			targetTeam: TeamPlayers,
+			expected:   true,
+		},
+		{
+			name:       "Shouldn't damage other bots on a deathmatch",
+			rules:      Rules{Mode: ModeDeathmatch},
+			shooterID:  shooter,
+			shooter:    TeamBots,
+			targetTeam: TeamBots,
+			expected:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{Rules: tt.rules}
+			assert.Equal(t, tt.expected, e.isHostile(tt.shooterID, tt.shooter, target, tt.targetTeam))
+		})
+	}
+}
+
+func TestFragLimit(t *testing.T) {
+	red := uuid.Must(uuid.NewV4())
+	blue := uuid.Must(uuid.NewV4())
+	e := &Engine{
+		GameMap: mapTest,
+		Rules:   Rules{Mode: ModeTeamDeathmatch, FragLimit: 2},
+		Score:   make(map[uuid.UUID]int),
+	}
+	assert.Nil(t, SetPlayers(
+		Entity{ID: red, Health: Health{Life: 1, Lives: 5}, Team: TeamRed},
+		Entity{ID: blue, Health: Health{Life: 1, Lives: 5}, Team: TeamBlue},
+	)(e))
+
+	e.damageEntity(blue, red, 1)
+	assert.Equal(t, 1, e.Frags[red])
+	assert.False(t, e.LevelComplete)
+
+	// The second kill is done once the respawn invulnerability is over
+	player, _ := e.Entity(blue)
+	player.Health.InvulnerableUntil = player.Health.InvulnerableUntil.Add(-respawnInvulnerability)
+	e.storeEntity(player)
+	e.damageEntity(blue, red, 1)
+	assert.True(t, e.LevelComplete)
+	assert.Equal(t, red, e.RoundWinner)
+	assert.Equal(t, TeamRed, e.WinningTeam)
+}
+
+func TestParseGameMode(t *testing.T) {
+	mode, err := ParseGameMode("team-deathmatch")
+	assert.Nil(t, err)
+	assert.Equal(t, ModeTeamDeathmatch, mode)
+	mode, err = ParseGameMode("Deathmatch")
+	assert.Nil(t, err)
+	assert.Equal(t, ModeDeathmatch, mode)
+	_, err = ParseGameMode("capture the flag")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,174 @@
+package game
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// playerActionRate is how many actions a player can do on each
+	// rateLimitWindow
+	playerActionRate = 40
+	rateLimitWindow  = time.Second
+)
+
+var (
+	// ErrUnknownEntity is the rejection for the actions of an entity that
+	// doesn't exist on the engine
+	ErrUnknownEntity = errors.New("unknown entity")
+	// ErrEntityExists is the rejection for a player joining with the id of an
+	// entity already on the engine
+	ErrEntityExists = errors.New("entity already exists")
+	// ErrEntityDead is the rejection for the actions of an eliminated player
+	ErrEntityDead = errors.New("entity is dead")
+	// ErrPaused is the rejection for the actions received while the game is
+	// paused
+	ErrPaused = errors.New("game is paused")
+	// ErrRateLimited is the rejection for the actions of a player doing more
+	// actions than allowed
+	ErrRateLimited = errors.New("rate limited")
+	// ErrCooldown is the rejection for the shoots of a weapon that is not
+	// ready yet
+	ErrCooldown = errors.New("weapon cooling down")
+	// ErrInvalidDirection is the rejection for the movements and shoots without
+	// one of the four directions
+	ErrInvalidDirection = errors.New("invalid direction")
+)
+
+// RejectHook is called each time an action is rejected with the reason of the
+// rejection
+type RejectHook func(action Action, reason error)
+
+// validation keeps the actions done by each player for the rate limit, the last
+// shoot of each ship for the cooldowns and how many actions were rejected for
+// each reason
+type validation struct {
+	mutex     sync.Mutex
+	windows   map[uuid.UUID]*rateWindow
+	lastShots map[uuid.UUID]time.Time
+	rejected  map[error]int
+}
+
+// rateWindow keeps how many actions a player did since the window started
+type rateWindow struct {
+	start   time.Time
+	actions int
+}
+
+// SetRejectHook will attach to the game engine a function called each time an
+// action is rejected
+func SetRejectHook(hook RejectHook) engineOpt {
+	return func(e *Engine) error {
+		e.OnReject = hook
+		return nil
+	}
+}
+
+// Perform will validate the given action and will perform it, when the action
+// is not valid it is discarded and the reason is returned
+func (e *Engine) Perform(action Action) error {
+	e.mutex.Lock()
+	err := e.validate(action)
+	if err == nil {
+		start := time.Now()
+		action.Perform(e)
+		e.recordTickTime(time.Since(start))
+	}
+	e.mutex.Unlock()
+	if err != nil {
+		e.reject(action, err)
+	}
+	return err
+}
+
+// validate returns the reason the given action can't be performed, nil if the
+// action is valid
+func (e *Engine) validate(action Action) error {
+	if e.paused {
+		return ErrPaused
+	}
+	return action.Validate(e)
+}
+
+// reject will discard the given action, count it and notify the reject hook
+func (e *Engine) reject(action Action, reason error) {
+	e.discard(action)
+	e.validation.mutex.Lock()
+	if e.validation.rejected == nil {
+		e.validation.rejected = make(map[error]int)
+	}
+	e.validation.rejected[reason]++
+	e.validation.mutex.Unlock()
+	if e.OnReject != nil {
+		e.OnReject(action, reason)
+	}
+}
+
+// Rejected returns how many actions were rejected for each reason
+func (e *Engine) Rejected() map[error]int {
+	e.validation.mutex.Lock()
+	defer e.validation.mutex.Unlock()
+	rejected := make(map[error]int, len(e.validation.rejected))
+	for reason, count := range e.validation.rejected {
+		rejected[reason] = count
+	}
+	return rejected
+}
+
+// validateEntity returns the reason the given entity can't do an action, nil
+// if he can. Each valid action of a player counts for his rate limit, the
+// windows follow the engine clock since the time of the actions comes from
+// the clients
+func (e *Engine) validateEntity(entityID uuid.UUID) error {
+	entity, exists := e.Entity(entityID)
+	if !exists {
+		return ErrUnknownEntity
+	}
+	if entity.Health.Eliminated {
+		return ErrEntityDead
+	}
+	if !entity.IsPlayer() {
+		return nil
+	}
+	now := time.Now()
+	e.validation.mutex.Lock()
+	defer e.validation.mutex.Unlock()
+	if e.validation.windows == nil {
+		e.validation.windows = make(map[uuid.UUID]*rateWindow)
+	}
+	window, exists := e.validation.windows[entityID]
+	if !exists || now.Sub(window.start) >= rateLimitWindow {
+		window = &rateWindow{start: now}
+		e.validation.windows[entityID] = window
+	}
+	if window.actions >= playerActionRate {
+		return ErrRateLimited
+	}
+	window.actions++
+	return nil
+}
+
+// validateCooldown returns ErrCooldown when the given weapon of the given
+// shooter is not ready yet on the engine clock
+func (e *Engine) validateCooldown(shooterID uuid.UUID, weapon Weapon) error {
+	e.validation.mutex.Lock()
+	defer e.validation.mutex.Unlock()
+	if lastShot, exists := e.validation.lastShots[shooterID]; exists && time.Since(lastShot) < weapon.Cooldown {
+		return ErrCooldown
+	}
+	return nil
+}
+
+// recordCooldown will keep the current time as the last shoot of the given
+// shooter
+func (e *Engine) recordCooldown(shooterID uuid.UUID) {
+	e.validation.mutex.Lock()
+	defer e.validation.mutex.Unlock()
+	if e.validation.lastShots == nil {
+		e.validation.lastShots = make(map[uuid.UUID]time.Time)
+	}
+	e.validation.lastShots[shooterID] = time.Now()
+}
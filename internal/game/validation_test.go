@@ -0,0 +1,160 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformValidation(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	deadID := uuid.Must(uuid.NewV4())
+	unknownID := uuid.Must(uuid.NewV4())
+	newEngine := func() *Engine {
+		e, err := NewEngine(
+			SetMap(mapTest),
+			SetPlayers(
+				Entity{ID: playerID, Health: Health{Life: 1}},
+				Entity{ID: deadID, Health: Health{Life: 1, Eliminated: true}},
+			),
+		)
+		assert.Nil(t, err)
+		return e
+	}
+	tests := []struct {
+		name     string
+		paused   bool
+		action   func(e *Engine) Action
+		expected error
+	}{
+		{
+			name: "Should perform a valid movement",
+			action: func(e *Engine) Action {
+				return &MoveAction{EntityID: playerID, Direction: DirectionRight, CreatedAt: time.Now()}
+			},
+			expected: nil,
+		},
+		{
+			name: "Should reject the movement of an unknown player",
+			action: func(e *Engine) Action {
+				return &MoveAction{EntityID: unknownID, Direction: DirectionRight, CreatedAt: time.Now()}
+			},
+			expected: ErrUnknownEntity,
+		},
+		{
+			name: "Should reject the movement of an eliminated player",
+			action: func(e *Engine) Action {
+				return &MoveAction{EntityID: deadID, Direction: DirectionRight, CreatedAt: time.Now()}
+			},
+			expected: ErrEntityDead,
+		},
+		{
+			name: "Should reject a movement without direction",
+			action: func(e *Engine) Action {
+				return &MoveAction{EntityID: playerID, Direction: DirectionNone, CreatedAt: time.Now()}
+			},
+			expected: ErrInvalidDirection,
+		},
+		{
+			name: "Should reject a shoot to an unknown direction",
+			action: func(e *Engine) Action {
+				return &FireAction{ShooterID: playerID, Direction: Direction(7), CreatedAt: time.Now()}
+			},
+			expected: ErrInvalidDirection,
+		},
+		{
+			name:   "Should reject the actions while the game is paused",
+			paused: true,
+			action: func(e *Engine) Action {
+				return &MoveAction{EntityID: playerID, Direction: DirectionRight, CreatedAt: time.Now()}
+			},
+			expected: ErrPaused,
+		},
+		{
+			name: "Should reject the shoot of an eliminated player",
+			action: func(e *Engine) Action {
+				return &FireAction{ShooterID: deadID, Direction: DirectionUp, CreatedAt: time.Now()}
+			},
+			expected: ErrEntityDead,
+		},
+		{
+			name: "Should reject the shoot of an unknown ship",
+			action: func(e *Engine) Action {
+				return &FireAction{ShooterID: unknownID, Direction: DirectionUp, CreatedAt: time.Now()}
+			},
+			expected: ErrUnknownEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEngine()
+			if tt.paused {
+				e.Pause()
+			}
+			var rejected []error
+			e.OnReject = func(action Action, reason error) {
+				rejected = append(rejected, reason)
+			}
+			assert.Equal(t, tt.expected, e.Perform(tt.action(e)))
+			if tt.expected == nil {
+				assert.Empty(t, rejected)
+				return
+			}
+			assert.Equal(t, []error{tt.expected}, rejected)
+			assert.Equal(t, map[error]int{tt.expected: 1}, e.Rejected())
+			// The rejected actions never create entities
+			assert.Len(t, e.Players(), 2)
+		})
+	}
+}
+
+func TestPerformRateLimit(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Health: Health{Life: 1}}),
+	)
+	assert.Nil(t, err)
+	now := time.Now()
+	for i := 0; i < playerActionRate; i++ {
+		assert.Nil(t, e.Perform(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: now}))
+	}
+	assert.Equal(t, ErrRateLimited, e.Perform(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: now}))
+	// The time of the action comes from the client, it can't start a new window
+	assert.Equal(t, ErrRateLimited, e.Perform(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: now.Add(rateLimitWindow)}))
+	// A new window starts after the rate limit window
+	e.validation.windows[playerID].start = now.Add(-rateLimitWindow)
+	assert.Nil(t, e.Perform(&MoveAction{EntityID: playerID, Direction: DirectionUp, CreatedAt: now}))
+}
+
+func TestFireAction(t *testing.T) {
+	playerID := uuid.Must(uuid.NewV4())
+	e, err := NewEngine(
+		SetMap(mapTest),
+		SetPlayers(Entity{ID: playerID, Health: Health{Life: 1}, Position: Point{X: 1, Y: 0}, Team: TeamRed}),
+	)
+	assert.Nil(t, err)
+	defer e.Stop()
+	now := time.Now()
+	assert.Nil(t, e.Perform(&FireAction{ShooterID: playerID, Direction: DirectionUp, CreatedAt: now}))
+
+	// The engine creates the laser from the shooter with his weapon
+	var lasers []Laser
+	e.Lasers.Range(func(key interface{}, value interface{}) bool {
+		lasers = append(lasers, value.(Laser))
+		return true
+	})
+	assert.Len(t, lasers, 1)
+	assert.Equal(t, playerID, lasers[0].ShooterID)
+	assert.Equal(t, TeamRed, lasers[0].Team)
+	assert.Equal(t, DefaultWeapon.Damage, lasers[0].Damage)
+
+	// The weapon needs to cool down before shoot again, whatever the time of
+	// the action says
+	assert.Equal(t, ErrCooldown, e.Perform(&FireAction{ShooterID: playerID, Direction: DirectionUp, CreatedAt: now}))
+	assert.Equal(t, ErrCooldown, e.Perform(&FireAction{ShooterID: playerID, Direction: DirectionUp, CreatedAt: now.Add(DefaultWeapon.Cooldown)}))
+	e.validation.lastShots[playerID] = time.Now().Add(-DefaultWeapon.Cooldown)
+	assert.Nil(t, e.Perform(&FireAction{ShooterID: playerID, Direction: DirectionUp, CreatedAt: now}))
+}
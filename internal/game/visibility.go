@@ -0,0 +1,66 @@
+package game
+
+// VisionRadius is how far, in tiles, the ships can see when the fog of war is
+// on
+const VisionRadius = 10
+
+// BlocksSight will check if the tile on the given position hides what is
+// behind it, the solid and destructible walls block the sight but the phase
+// walls can be seen through as the lasers go through them
+func (m Map) BlocksSight(p Point) bool {
+	return m.IsWall(p)
+}
+
+// LineOfSight will check if nothing blocks the sight between the given
+// positions, the tiles on both ends never block it so the walls can be seen.
+// The line is drawn with the Bresenham algorithm
+func (m Map) LineOfSight(from Point, to Point) bool {
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	stepX, stepY := sign(to.X-from.X), sign(to.Y-from.Y)
+	err := dx + dy
+	for p := from; p != to; {
+		if p != from && m.BlocksSight(p) {
+			return false
+		}
+		double := 2 * err
+		if double >= dy {
+			err += dy
+			p.X += stepX
+		}
+		if double <= dx {
+			err += dx
+			p.Y += stepY
+		}
+	}
+	return true
+}
+
+// VisibleFrom returns the positions of the map that can be seen from the given
+// position, only the tiles inside the given radius are checked
+func (m Map) VisibleFrom(from Point, radius int) map[Point]bool {
+	visible := make(map[Point]bool)
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			p := Point{X: from.X + x, Y: from.Y + y}
+			if x*x+y*y > radius*radius {
+				continue
+			}
+			if _, _, ok := m.toMapIndex(p); ok && m.LineOfSight(from, p) {
+				visible[p] = true
+			}
+		}
+	}
+	return visible
+}
+
+// CanSee will check if the given entity sees the given position, it has to be
+// inside his vision radius and without walls between them
+func (e *Engine) CanSee(viewer Entity, p Point) bool {
+	dx, dy := p.X-viewer.Position.X, p.Y-viewer.Position.Y
+	if dx*dx+dy*dy > VisionRadius*VisionRadius {
+		return false
+	}
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.GameMap.LineOfSight(viewer.Position, p)
+}
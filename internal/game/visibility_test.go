@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var mapSight = Map{
+	[]rune("█████████"),
+	[]rune("█   █   █"),
+	[]rune("█   #   █"),
+	[]rune("█   ▓   █"),
+	[]rune("█████████"),
+}
+
+func TestLineOfSightMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     Point
+		to       Point
+		expected bool
+	}{
+		{name: "Should see itself", from: Point{X: -3, Y: 0}, to: Point{X: -3, Y: 0}, expected: true},
+		{name: "Should see an open tile", from: Point{X: -3, Y: -1}, to: Point{X: -1, Y: 1}, expected: true},
+		{name: "Should see the wall blocking the sight", from: Point{X: -3, Y: -1}, to: Point{X: 0, Y: -1}, expected: true},
+		{name: "Shouldn't see through a solid wall", from: Point{X: -3, Y: -1}, to: Point{X: 3, Y: -1}, expected: false},
+		{name: "Shouldn't see through a destructible wall", from: Point{X: -3, Y: 1}, to: Point{X: 3, Y: 1}, expected: false},
+		{name: "Should see through a phase wall", from: Point{X: -3, Y: 0}, to: Point{X: 3, Y: 0}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mapSight.LineOfSight(tt.from, tt.to))
+			assert.Equal(t, tt.expected, mapSight.LineOfSight(tt.to, tt.from), "The sight should be the same on both ways")
+		})
+	}
+}
+
+func TestVisibleFromMethod(t *testing.T) {
+	visible := mapSight.VisibleFrom(Point{X: -3, Y: -1}, 2)
+	assert.True(t, visible[Point{X: -3, Y: -1}])
+	assert.True(t, visible[Point{X: -1, Y: -1}])
+	assert.True(t, visible[Point{X: -4, Y: -2}], "The walls should be visible")
+	assert.False(t, visible[Point{X: 0, Y: -1}], "The tiles out of the radius shouldn't be visible")
+	assert.False(t, visible[Point{X: -5, Y: -1}], "The tiles out of the map shouldn't be visible")
+
+	visible = mapSight.VisibleFrom(Point{X: -1, Y: -1}, VisionRadius)
+	assert.True(t, visible[Point{X: 0, Y: -1}])
+	assert.False(t, visible[Point{X: 1, Y: -1}], "The tiles behind the walls shouldn't be visible")
+	assert.True(t, visible[Point{X: 1, Y: 0}], "The tiles behind the phase walls should be visible")
+}
+
+func TestAimDirectionFogOfWar(t *testing.T) {
+	bot := Entity{Position: Point{X: 3, Y: -1}, Controller: Controller{Kind: ControllerBot}}
+	e := &Engine{Difficulty: DifficultyNightmare, GameMap: mapSight}
+	e.storeEntity(Entity{ID: uuid.Must(uuid.NewV4()), Position: Point{X: -3, Y: -1}})
+	original := difficultySettings[DifficultyNightmare]
+	difficultySettings[DifficultyNightmare] = DifficultySettings{Accuracy: 1}
+	defer func() { difficultySettings[DifficultyNightmare] = original }()
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, DirectionLeft, e.aimDirection(bot), "Without fog the bot aims through the walls")
+	}
+	e.Rules.FogOfWar = true
+	assert.False(t, e.CanSee(bot, Point{X: -3, Y: -1}))
+	hidden := 0
+	for i := 0; i < 20; i++ {
+		if e.aimDirection(bot) != DirectionLeft {
+			hidden++
+		}
+	}
+	assert.NotZero(t, hidden, "The bot shouldn't aim at the players he can't see")
+}
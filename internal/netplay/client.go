@@ -0,0 +1,215 @@
+package netplay
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+const (
+	// InterpolationDelay is how far in the past the other entities are
+	// rendered, so there are two snapshots to interpolate between them
+	InterpolationDelay = 100 * time.Millisecond
+	// snapshotHistory is how many snapshots the client keeps for interpolate
+	snapshotHistory = 32
+)
+
+// Client applies the inputs of his player on a local copy of the map right
+// away, without waiting for the server, and corrects his prediction when the
+// snapshots of the server arrive. The rest of the entities are interpolated
+// between the last snapshots
+type Client struct {
+	conn    Conn
+	mutex   sync.Mutex
+	gameMap game.Map
+	// player is the predicted state of the player of the client
+	player   game.Entity
+	sequence uint32
+	// pending keeps the inputs sent but not acknowledged by the server yet
+	pending   []Input
+	snapshots []receivedSnapshot
+	// decoder rebuilds the snapshots from the deltas sent by the server
+	decoder DeltaDecoder
+}
+
+// receivedSnapshot is a snapshot with the time it arrived
+type receivedSnapshot struct {
+	Snapshot
+	receivedAt time.Time
+}
+
+// NewClient will build a new client for the given player, the movements are
+// predicted on a copy of the given map
+func NewClient(conn Conn, player game.Entity, gameMap game.Map) *Client {
+	return &Client{
+		conn:    conn,
+		gameMap: gameMap.Copy(),
+		player:  player,
+	}
+}
+
+// Player returns the predicted state of the player of the client
+func (c *Client) Player() game.Entity {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.player
+}
+
+// Pending returns how many inputs are waiting for the server
+func (c *Client) Pending() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.pending)
+}
+
+// Move will move the player on the given direction at the given time, the
+// movement is applied locally and sent to the server
+func (c *Client) Move(direction game.Direction, now time.Time) error {
+	return c.send(Input{Move: direction, CreatedAt: now})
+}
+
+// Fire will ask the server to shoot on the given direction, the lasers are not
+// predicted
+func (c *Client) Fire(direction game.Direction, now time.Time) error {
+	return c.send(Input{Fire: direction, CreatedAt: now})
+}
+
+// send will tag the given input with the next sequence, will predict it and
+// will send it to the server
+func (c *Client) send(input Input) error {
+	c.mutex.Lock()
+	c.sequence++
+	input.Sequence = c.sequence
+	c.player = c.predict(c.player, input)
+	c.pending = append(c.pending, input)
+	c.mutex.Unlock()
+	return c.conn.Send(input)
+}
+
+// predict returns the given player after applying the given input on the local
+// map
+func (c *Client) predict(player game.Entity, input Input) game.Entity {
+	if input.Move == game.DirectionNone {
+		return player
+	}
+	moved, _ := c.gameMap.Move(player, input.Move, input.CreatedAt)
+	return moved
+}
+
+// Update will process all the snapshots arrived from the server, the given
+// time is when they are received. Each delta is acknowledged so the server
+// builds the next ones over it, a delta that can't be decoded asks the server
+// for a full snapshot
+func (c *Client) Update(now time.Time) {
+	for {
+		select {
+		case message := <-c.conn.Receive():
+			switch message := message.(type) {
+			case Snapshot:
+				c.reconcile(message, now)
+			case Delta:
+				snapshot, err := c.decoder.Decode(message)
+				if err != nil {
+					c.conn.Send(SnapshotAck{})
+					continue
+				}
+				c.reconcile(snapshot, now)
+				c.conn.Send(SnapshotAck{Tick: snapshot.Tick})
+			}
+		default:
+			return
+		}
+	}
+}
+
+// reconcile will take the state of the player from the given snapshot and it
+// will apply again the inputs the server has not processed yet
+func (c *Client) reconcile(snapshot Snapshot, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.snapshots = append(c.snapshots, receivedSnapshot{Snapshot: snapshot, receivedAt: now})
+	if len(c.snapshots) > snapshotHistory {
+		c.snapshots = c.snapshots[1:]
+	}
+	pending := c.pending[:0]
+	for _, input := range c.pending {
+		if input.Sequence > snapshot.Acknowledged {
+			pending = append(pending, input)
+		}
+	}
+	c.pending = pending
+	state, exists := snapshot.entity(c.player.ID)
+	if !exists {
+		return
+	}
+	c.player.Position = state.Position
+	c.player.Health.Life = state.Life
+	c.player.Health.Lives = state.Lives
+	c.player.Health.Eliminated = state.Eliminated
+	for _, input := range c.pending {
+		c.player = c.predict(c.player, input)
+	}
+}
+
+// Entities returns the state of the other entities at the given time, the
+// positions are interpolated between the snapshots around the given time minus
+// the interpolation delay
+func (c *Client) Entities(now time.Time) []EntityState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.snapshots) == 0 {
+		return nil
+	}
+	// Before the first snapshot or after the last one there is nothing to
+	// interpolate, the closest snapshot is used
+	renderAt := now.Add(-InterpolationDelay)
+	last := len(c.snapshots) - 1
+	next := last
+	for i, snapshot := range c.snapshots {
+		if !snapshot.receivedAt.Before(renderAt) {
+			next = i
+			break
+		}
+	}
+	from, to := c.snapshots[next], c.snapshots[next]
+	if next > 0 && !c.snapshots[last].receivedAt.Before(renderAt) {
+		from = c.snapshots[next-1]
+	}
+	progress := 1.0
+	if span := to.receivedAt.Sub(from.receivedAt); span > 0 {
+		progress = float64(renderAt.Sub(from.receivedAt)) / float64(span)
+	}
+	var entities []EntityState
+	for _, state := range to.Entities {
+		if state.ID == c.player.ID {
+			continue
+		}
+		if previous, exists := from.entity(state.ID); exists {
+			state.Position = interpolate(previous.Position, state.Position, progress)
+		}
+		entities = append(entities, state)
+	}
+	return entities
+}
+
+// Lasers returns the lasers of the last snapshot, they move too fast for being
+// interpolated
+func (c *Client) Lasers() []LaserState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.snapshots) == 0 {
+		return nil
+	}
+	return c.snapshots[len(c.snapshots)-1].Lasers
+}
+
+// interpolate returns the position between the given ones at the given
+// progress, from zero to one, rounded to the nearest tile
+func interpolate(from game.Point, to game.Point, progress float64) game.Point {
+	return game.Point{
+		X: from.X + int(math.Round(float64(to.X-from.X)*progress)),
+		Y: from.Y + int(math.Round(float64(to.Y-from.Y)*progress)),
+	}
+}
@@ -0,0 +1,278 @@
+package netplay
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// deltaHistory is how many snapshots the server keeps for each client while
+// waiting for his acknowledgement, once the acknowledged one is gone the
+// server sends a full snapshot again
+const deltaHistory = 64
+
+// ErrMissingBaseline is returned when a delta is built over a snapshot the
+// client doesn't have
+var ErrMissingBaseline = errors.New("missing baseline snapshot")
+
+// Delta is a snapshot sent as the differences with a baseline snapshot, the
+// last one acknowledged by the client. Without baseline the delta has the
+// whole state as created entities and lasers
+type Delta struct {
+	Tick uint64 `json:"tick"`
+	// Baseline is the tick of the snapshot the delta is built over, zero for
+	// a full snapshot
+	Baseline      uint64         `json:"baseline,omitempty"`
+	Acknowledged  uint32         `json:"acknowledged,omitempty"`
+	Created       []EntityState  `json:"created,omitempty"`
+	Changed       []EntityChange `json:"changed,omitempty"`
+	Removed       []uuid.UUID    `json:"removed,omitempty"`
+	CreatedLasers []LaserState   `json:"createdLasers,omitempty"`
+	MovedLasers   []LaserState   `json:"movedLasers,omitempty"`
+	RemovedLasers []uuid.UUID    `json:"removedLasers,omitempty"`
+}
+
+// EntityChange keeps the fields of an entity that changed since the baseline,
+// the fields without change are nil
+type EntityChange struct {
+	ID         uuid.UUID   `json:"id"`
+	Name       *string     `json:"name,omitempty"`
+	Position   *game.Point `json:"position,omitempty"`
+	Life       *int        `json:"life,omitempty"`
+	Lives      *int        `json:"lives,omitempty"`
+	Eliminated *bool       `json:"eliminated,omitempty"`
+	Glyph      *rune       `json:"glyph,omitempty"`
+	Color      *string     `json:"color,omitempty"`
+}
+
+// SnapshotAck is sent by the client for each snapshot received, so the server
+// can build the next deltas over it. The tick zero asks for a full snapshot
+type SnapshotAck struct {
+	Tick uint64 `json:"tick"`
+}
+
+// DeltaEncoder builds the deltas sent to a client, it keeps the snapshots sent
+// until the client acknowledges them
+type DeltaEncoder struct {
+	mutex    sync.Mutex
+	sent     map[uint64]Snapshot
+	baseline uint64
+}
+
+// Encode returns the given snapshot as a delta over the last snapshot
+// acknowledged by the client, or as a full snapshot when there is none
+func (d *DeltaEncoder) Encode(snapshot Snapshot) Delta {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.sent == nil {
+		d.sent = make(map[uint64]Snapshot)
+	}
+	// A client that doesn't acknowledge anything for a while gets full
+	// snapshots until he does it again
+	if len(d.sent) >= deltaHistory {
+		for tick := range d.sent {
+			if tick != d.baseline {
+				delete(d.sent, tick)
+			}
+		}
+	}
+	d.sent[snapshot.Tick] = snapshot
+	baseline, exists := d.sent[d.baseline]
+	if d.baseline == 0 || !exists {
+		return diff(Snapshot{}, snapshot)
+	}
+	delta := diff(baseline, snapshot)
+	delta.Baseline = d.baseline
+	return delta
+}
+
+// Acknowledge will take the snapshot with the given tick as the baseline of
+// the next deltas, the tick zero drops the baseline so a full snapshot is sent
+func (d *DeltaEncoder) Acknowledge(tick uint64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if tick == 0 {
+		d.baseline = 0
+		return
+	}
+	if _, exists := d.sent[tick]; !exists || tick <= d.baseline {
+		return
+	}
+	d.baseline = tick
+	for sent := range d.sent {
+		if sent < tick {
+			delete(d.sent, sent)
+		}
+	}
+}
+
+// DeltaDecoder rebuilds the snapshots from the deltas received by a client
+type DeltaDecoder struct {
+	received map[uint64]Snapshot
+}
+
+// Decode returns the snapshot of the given delta, it fails when the baseline
+// of the delta was never received
+func (d *DeltaDecoder) Decode(delta Delta) (Snapshot, error) {
+	if d.received == nil {
+		d.received = make(map[uint64]Snapshot)
+	}
+	var baseline Snapshot
+	if delta.Baseline != 0 {
+		var exists bool
+		if baseline, exists = d.received[delta.Baseline]; !exists {
+			return Snapshot{}, ErrMissingBaseline
+		}
+	}
+	snapshot := apply(baseline, delta)
+	// The server never goes back to an older baseline
+	for tick := range d.received {
+		if tick < delta.Baseline {
+			delete(d.received, tick)
+		}
+	}
+	d.received[snapshot.Tick] = snapshot
+	return snapshot, nil
+}
+
+// diff returns the delta that turns the given baseline into the given snapshot
+func diff(baseline Snapshot, snapshot Snapshot) Delta {
+	delta := Delta{Tick: snapshot.Tick, Acknowledged: snapshot.Acknowledged}
+	previous := make(map[uuid.UUID]EntityState, len(baseline.Entities))
+	for _, state := range baseline.Entities {
+		previous[state.ID] = state
+	}
+	for _, state := range snapshot.Entities {
+		old, exists := previous[state.ID]
+		delete(previous, state.ID)
+		if !exists {
+			delta.Created = append(delta.Created, state)
+			continue
+		}
+		if change, changed := entityChange(old, state); changed {
+			delta.Changed = append(delta.Changed, change)
+		}
+	}
+	for id := range previous {
+		delta.Removed = append(delta.Removed, id)
+	}
+	previousLasers := make(map[uuid.UUID]LaserState, len(baseline.Lasers))
+	for _, laser := range baseline.Lasers {
+		previousLasers[laser.ID] = laser
+	}
+	for _, laser := range snapshot.Lasers {
+		old, exists := previousLasers[laser.ID]
+		delete(previousLasers, laser.ID)
+		switch {
+		case !exists:
+			delta.CreatedLasers = append(delta.CreatedLasers, laser)
+		case old.Position != laser.Position:
+			delta.MovedLasers = append(delta.MovedLasers, laser)
+		}
+	}
+	for id := range previousLasers {
+		delta.RemovedLasers = append(delta.RemovedLasers, id)
+	}
+	sortIDs(delta.Removed)
+	sortIDs(delta.RemovedLasers)
+	return delta
+}
+
+// entityChange returns the fields changed between the given states, false
+// when nothing changed
+func entityChange(old EntityState, state EntityState) (EntityChange, bool) {
+	change := EntityChange{ID: state.ID}
+	changed := false
+	if old.Name != state.Name {
+		change.Name, changed = &state.Name, true
+	}
+	if old.Position != state.Position {
+		change.Position, changed = &state.Position, true
+	}
+	if old.Life != state.Life {
+		change.Life, changed = &state.Life, true
+	}
+	if old.Lives != state.Lives {
+		change.Lives, changed = &state.Lives, true
+	}
+	if old.Eliminated != state.Eliminated {
+		change.Eliminated, changed = &state.Eliminated, true
+	}
+	if old.Glyph != state.Glyph {
+		change.Glyph, changed = &state.Glyph, true
+	}
+	if old.Color != state.Color {
+		change.Color, changed = &state.Color, true
+	}
+	return change, changed
+}
+
+// apply returns the snapshot resulting of applying the given delta over the
+// given baseline, the baseline is not modified
+func apply(baseline Snapshot, delta Delta) Snapshot {
+	snapshot := Snapshot{Tick: delta.Tick, Acknowledged: delta.Acknowledged}
+	entities := make(map[uuid.UUID]EntityState, len(baseline.Entities)+len(delta.Created))
+	for _, state := range baseline.Entities {
+		entities[state.ID] = state
+	}
+	for _, id := range delta.Removed {
+		delete(entities, id)
+	}
+	for _, change := range delta.Changed {
+		state := entities[change.ID]
+		state.ID = change.ID
+		if change.Name != nil {
+			state.Name = *change.Name
+		}
+		if change.Position != nil {
+			state.Position = *change.Position
+		}
+		if change.Life != nil {
+			state.Life = *change.Life
+		}
+		if change.Lives != nil {
+			state.Lives = *change.Lives
+		}
+		if change.Eliminated != nil {
+			state.Eliminated = *change.Eliminated
+		}
+		if change.Glyph != nil {
+			state.Glyph = *change.Glyph
+		}
+		if change.Color != nil {
+			state.Color = *change.Color
+		}
+		entities[change.ID] = state
+	}
+	for _, state := range delta.Created {
+		entities[state.ID] = state
+	}
+	for _, state := range entities {
+		snapshot.Entities = append(snapshot.Entities, state)
+	}
+	lasers := make(map[uuid.UUID]LaserState, len(baseline.Lasers)+len(delta.CreatedLasers))
+	for _, laser := range baseline.Lasers {
+		lasers[laser.ID] = laser
+	}
+	for _, id := range delta.RemovedLasers {
+		delete(lasers, id)
+	}
+	for _, laser := range append(delta.CreatedLasers, delta.MovedLasers...) {
+		lasers[laser.ID] = laser
+	}
+	for _, laser := range lasers {
+		snapshot.Lasers = append(snapshot.Lasers, laser)
+	}
+	snapshot.sort()
+	return snapshot
+}
+
+// sortIDs will sort the given ids, so the same changes give the same delta
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+}
@@ -0,0 +1,175 @@
+package netplay
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSnapshot returns a snapshot with the given tick, entities and lasers
+// sorted as the server does
+func newSnapshot(tick uint64, entities []EntityState, lasers []LaserState) Snapshot {
+	snapshot := Snapshot{
+		Tick:     tick,
+		Entities: append([]EntityState{}, entities...),
+		Lasers:   append([]LaserState{}, lasers...),
+	}
+	snapshot.sort()
+	return snapshot
+}
+
+func TestDeltaRoundTrip(t *testing.T) {
+	player := EntityState{ID: uuid.Must(uuid.NewV4()), Name: "Player", Life: 3, Lives: 3, Glyph: '^', Color: "green"}
+	enemy := EntityState{ID: uuid.Must(uuid.NewV4()), Name: "Enemy", Position: game.Point{X: 3, Y: 1}, Life: 1, Glyph: 'X', Color: "red"}
+	laser := LaserState{ID: uuid.Must(uuid.NewV4()), Position: game.Point{X: 1, Y: 0}}
+	spawned := EntityState{ID: uuid.Must(uuid.NewV4()), Name: "Enemy", Position: game.Point{X: -3, Y: 1}, Life: 1, Glyph: 'X', Color: "red"}
+	shot := LaserState{ID: uuid.Must(uuid.NewV4()), Position: game.Point{X: 2, Y: 1}}
+
+	moved := player
+	moved.Position = game.Point{X: 1, Y: 0}
+	hit := enemy
+	hit.Life = 0
+	movedLaser := laser
+	movedLaser.Position = game.Point{X: 2, Y: 0}
+
+	baseline := newSnapshot(1, []EntityState{player, enemy}, []LaserState{laser})
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		expected Delta
+	}{
+		{
+			name:     "Nothing changed",
+			snapshot: newSnapshot(2, []EntityState{player, enemy}, []LaserState{laser}),
+			expected: Delta{Tick: 2, Baseline: 1},
+		},
+		{
+			name:     "Only the changed fields are sent",
+			snapshot: newSnapshot(2, []EntityState{moved, enemy}, []LaserState{movedLaser}),
+			expected: Delta{
+				Tick:        2,
+				Baseline:    1,
+				Changed:     []EntityChange{{ID: player.ID, Position: &moved.Position}},
+				MovedLasers: []LaserState{movedLaser},
+			},
+		},
+		{
+			name:     "Created and removed",
+			snapshot: newSnapshot(2, []EntityState{player, hit, spawned}, []LaserState{shot}),
+			expected: Delta{
+				Tick:          2,
+				Baseline:      1,
+				Created:       []EntityState{spawned},
+				Changed:       []EntityChange{{ID: enemy.ID, Life: &hit.Life}},
+				CreatedLasers: []LaserState{shot},
+				RemovedLasers: []uuid.UUID{laser.ID},
+			},
+		},
+		{
+			name:     "Removed entity",
+			snapshot: newSnapshot(2, []EntityState{player}, nil),
+			expected: Delta{
+				Tick:          2,
+				Baseline:      1,
+				Removed:       []uuid.UUID{enemy.ID},
+				RemovedLasers: []uuid.UUID{laser.ID},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder := &DeltaEncoder{}
+			decoder := &DeltaDecoder{}
+			full := encoder.Encode(baseline)
+			assert.Equal(t, uint64(0), full.Baseline, "Without acknowledge the snapshot should be full")
+			decoded, err := decoder.Decode(full)
+			assert.Nil(t, err)
+			assert.Equal(t, baseline, decoded)
+			encoder.Acknowledge(decoded.Tick)
+
+			delta := encoder.Encode(tt.snapshot)
+			assert.Equal(t, tt.expected, delta)
+			decoded, err = decoder.Decode(delta)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.snapshot.Entities, decoded.Entities)
+			assert.ElementsMatch(t, tt.snapshot.Lasers, decoded.Lasers)
+		})
+	}
+}
+
+func TestDeltaBaselineFallback(t *testing.T) {
+	player := EntityState{ID: uuid.Must(uuid.NewV4()), Name: "Player", Life: 3, Lives: 3}
+	snapshot := func(tick uint64) Snapshot {
+		moved := player
+		moved.Position = game.Point{X: int(tick)}
+		return newSnapshot(tick, []EntityState{moved}, nil)
+	}
+
+	encoder := &DeltaEncoder{}
+	encoder.Acknowledge(5)
+	assert.Equal(t, uint64(0), encoder.Encode(snapshot(1)).Baseline, "A tick never sent can't be the baseline")
+	encoder.Acknowledge(1)
+	assert.Equal(t, uint64(1), encoder.Encode(snapshot(2)).Baseline)
+	encoder.Acknowledge(0)
+	assert.Equal(t, uint64(0), encoder.Encode(snapshot(3)).Baseline, "The client asked for a full snapshot")
+
+	encoder.Acknowledge(3)
+	for tick := uint64(4); tick < 4+deltaHistory; tick++ {
+		assert.Equal(t, uint64(3), encoder.Encode(snapshot(tick)).Baseline, "The baseline should be kept while the client doesn't acknowledge")
+	}
+	encoder.Acknowledge(4)
+	assert.Equal(t, uint64(3), encoder.Encode(snapshot(4+deltaHistory)).Baseline, "The snapshots not acknowledged in time should be dropped")
+
+	decoder := &DeltaDecoder{}
+	_, err := decoder.Decode(Delta{Tick: 9, Baseline: 8})
+	assert.Equal(t, ErrMissingBaseline, err)
+}
+
+func TestDeltaSize(t *testing.T) {
+	var entities []EntityState
+	for i := 0; i < 20; i++ {
+		entities = append(entities, EntityState{ID: uuid.Must(uuid.NewV4()), Name: "Enemy", Position: game.Point{X: i, Y: i}, Life: 1, Glyph: 'X', Color: "red"})
+	}
+	baseline := newSnapshot(1, entities, nil)
+	entities[0].Position.X++
+	next := newSnapshot(2, entities, nil)
+
+	encoder := &DeltaEncoder{}
+	encoder.Encode(baseline)
+	encoder.Acknowledge(1)
+	delta, err := json.Marshal(encoder.Encode(next))
+	assert.Nil(t, err)
+	full, err := json.Marshal(next)
+	assert.Nil(t, err)
+	assert.True(t, len(delta)*10 < len(full), "The delta should be much smaller than the full snapshot")
+}
+
+func TestClientAcknowledgesSnapshots(t *testing.T) {
+	engine, server, client := newMatch(t, 10*time.Millisecond, mapNetplay)
+	defer engine.Stop()
+	server.mutex.Lock()
+	remote := server.clients[client.Player().ID]
+	server.mutex.Unlock()
+
+	server.Broadcast()
+	waitFor(t, func() bool {
+		client.Update(time.Now())
+		remote.encoder.mutex.Lock()
+		defer remote.encoder.mutex.Unlock()
+		return remote.encoder.baseline != 0
+	})
+
+	assert.Nil(t, client.Move(game.DirectionRight, time.Now()))
+	waitFor(t, func() bool {
+		server.Broadcast()
+		client.Update(time.Now())
+		return client.Pending() == 0
+	})
+	assert.Equal(t, game.Point{X: 1, Y: 0}, client.Player().Position)
+	assert.Len(t, client.Entities(time.Now()), 0, "The only entity is the player of the client")
+}
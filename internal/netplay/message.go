@@ -0,0 +1,87 @@
+package netplay
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// Input is an action of a client sent to the server, each input has the next
+// sequence number so the client knows which inputs the server has processed
+type Input struct {
+	Sequence uint32 `json:"sequence"`
+	// Move is the direction where the ship moves, DirectionNone for not
+	// moving
+	Move game.Direction `json:"move"`
+	// Fire is the direction where the ship shoots, DirectionNone for not
+	// shooting
+	Fire      game.Direction `json:"fire"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// EntityState keeps the part of an entity the clients need for render it
+type EntityState struct {
+	ID       uuid.UUID  `json:"id"`
+	Name     string     `json:"name"`
+	Position game.Point `json:"position"`
+	Life     int        `json:"life"`
+	Lives    int        `json:"lives"`
+	// Eliminated is the flag that determines when the player has no lives
+	// left, the eliminated players are not rendered
+	Eliminated bool   `json:"eliminated"`
+	Glyph      rune   `json:"glyph"`
+	Color      string `json:"color"`
+}
+
+// LaserState keeps the part of a laser the clients need for render it
+type LaserState struct {
+	ID       uuid.UUID  `json:"id"`
+	Position game.Point `json:"position"`
+}
+
+// Snapshot is the state of the match sent by the server on each tick
+type Snapshot struct {
+	Tick uint64 `json:"tick"`
+	// Acknowledged is the sequence of the last input of the client processed
+	// by the server, the state already includes it
+	Acknowledged uint32        `json:"acknowledged"`
+	Entities     []EntityState `json:"entities"`
+	Lasers       []LaserState  `json:"lasers"`
+}
+
+// entityState returns the state of the given entity
+func entityState(entity game.Entity) EntityState {
+	return EntityState{
+		ID:         entity.ID,
+		Name:       entity.Name,
+		Position:   entity.Position,
+		Life:       entity.Health.Life,
+		Lives:      entity.Health.Lives,
+		Eliminated: entity.Health.Eliminated,
+		Glyph:      entity.Renderable.Glyph,
+		Color:      entity.Renderable.Color,
+	}
+}
+
+// entity returns the state of the entity with the given id on the snapshot
+func (s Snapshot) entity(id uuid.UUID) (EntityState, bool) {
+	for _, state := range s.Entities {
+		if state.ID == id {
+			return state, true
+		}
+	}
+	return EntityState{}, false
+}
+
+// sort will sort the entities and the lasers of the snapshot by his id, so the
+// same state gives the same snapshot
+func (s Snapshot) sort() {
+	sort.Slice(s.Entities, func(i, j int) bool {
+		return s.Entities[i].ID.String() < s.Entities[j].ID.String()
+	})
+	sort.Slice(s.Lasers, func(i, j int) bool {
+		return s.Lasers[i].ID.String() < s.Lasers[j].ID.String()
+	})
+}
@@ -0,0 +1,219 @@
+package netplay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+var mapNetplay = game.Map{
+	[]rune("█████████"),
+	[]rune("█       █"),
+	[]rune("█   1   █"),
+	[]rune("█       █"),
+	[]rune("█████████"),
+}
+
+// newMatch returns a started engine with a single player and a client of that
+// player connected to a server with the given latency
+func newMatch(t *testing.T, latency time.Duration, clientMap game.Map) (*game.Engine, *Server, *Client) {
+	player := game.NewPlayer("Remote", game.DifficultyNormal)
+	engine, err := game.NewEngine(game.SetMap(mapNetplay.Copy()), game.SetPlayers(player))
+	assert.Nil(t, err)
+	engine.Start()
+	player, _ = engine.Entity(player.ID)
+	clientConn, serverConn := Pipe(latency)
+	server := NewServer(engine)
+	server.Join(player.ID, serverConn)
+	return engine, server, NewClient(clientConn, player, clientMap)
+}
+
+// waitFor will check the given condition until it is true or the time is over
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("The condition was never true")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	client, server := Pipe(50 * time.Millisecond)
+	defer client.Close()
+	start := time.Now()
+	for i := 1; i <= 3; i++ {
+		assert.Nil(t, client.Send(i))
+	}
+	select {
+	case <-server.Receive():
+		t.Fatal("The message shouldn't arrive before the latency")
+	case <-time.After(20 * time.Millisecond):
+	}
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, i, <-server.Receive())
+	}
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	assert.Nil(t, server.Close())
+	<-client.Done()
+	assert.Equal(t, ErrClosed, client.Send(4))
+}
+
+func TestClientPrediction(t *testing.T) {
+	engine, server, client := newMatch(t, 50*time.Millisecond, mapNetplay)
+	defer engine.Stop()
+	playerID := client.Player().ID
+
+	assert.Nil(t, client.Move(game.DirectionRight, time.Now()))
+	assert.Equal(t, game.Point{X: 1, Y: 0}, client.Player().Position, "The movement should be applied right away")
+	remote, _ := engine.Entity(playerID)
+	assert.Equal(t, game.Point{X: 0, Y: 0}, remote.Position, "The server shouldn't know the movement yet")
+	assert.Equal(t, 1, client.Pending())
+
+	waitFor(t, func() bool {
+		remote, _ := engine.Entity(playerID)
+		return remote.Position == game.Point{X: 1, Y: 0}
+	})
+	server.Broadcast()
+	waitFor(t, func() bool {
+		client.Update(time.Now())
+		return client.Pending() == 0
+	})
+	assert.Equal(t, game.Point{X: 1, Y: 0}, client.Player().Position)
+}
+
+func TestClientMisprediction(t *testing.T) {
+	// The client doesn't know about the wall on the right of the player
+	clientMap := mapNetplay.Copy()
+	engine, server, client := newMatch(t, 10*time.Millisecond, clientMap)
+	defer engine.Stop()
+	engine.GameMap[2][5] = '█'
+
+	assert.Nil(t, client.Move(game.DirectionRight, time.Now()))
+	assert.Nil(t, client.Move(game.DirectionRight, time.Now()))
+	assert.Equal(t, game.Point{X: 2, Y: 0}, client.Player().Position)
+	waitFor(t, func() bool {
+		server.Broadcast()
+		client.Update(time.Now())
+		return client.Pending() == 0
+	})
+	assert.Equal(t, game.Point{X: 0, Y: 0}, client.Player().Position, "The prediction should be corrected by the server")
+}
+
+func TestClientReconcile(t *testing.T) {
+	conn, _ := Pipe(0)
+	defer conn.Close()
+	player := game.Entity{ID: uuid.Must(uuid.NewV4())}
+	client := NewClient(conn, player, mapNetplay)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, client.Move(game.DirectionRight, now))
+	}
+	assert.Equal(t, game.Point{X: 3, Y: 0}, client.Player().Position)
+
+	// The server moved the player somewhere else while processing the first
+	// input, the other two are applied again from there
+	client.reconcile(Snapshot{
+		Acknowledged: 1,
+		Entities:     []EntityState{{ID: player.ID, Position: game.Point{X: -2, Y: 1}, Life: 2}},
+	}, now)
+	assert.Equal(t, 2, client.Pending())
+	assert.Equal(t, game.Point{X: 0, Y: 1}, client.Player().Position)
+	assert.Equal(t, 2, client.Player().Health.Life)
+}
+
+func TestClientInterpolation(t *testing.T) {
+	conn, _ := Pipe(0)
+	defer conn.Close()
+	player := game.Entity{ID: uuid.Must(uuid.NewV4())}
+	bot := uuid.Must(uuid.NewV4())
+	client := NewClient(conn, player, mapNetplay)
+	start := time.Now()
+	client.reconcile(Snapshot{Tick: 1, Entities: []EntityState{
+		{ID: player.ID},
+		{ID: bot, Position: game.Point{X: -2, Y: 0}},
+	}}, start)
+	client.reconcile(Snapshot{Tick: 2, Entities: []EntityState{
+		{ID: player.ID},
+		{ID: bot, Position: game.Point{X: 2, Y: 0}},
+	}, Lasers: []LaserState{{ID: bot, Position: game.Point{X: 1, Y: 1}}}}, start.Add(100*time.Millisecond))
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected game.Point
+	}{
+		{name: "Should use the first snapshot before it", now: start, expected: game.Point{X: -2, Y: 0}},
+		{name: "Should interpolate between the snapshots", now: start.Add(InterpolationDelay + 50*time.Millisecond), expected: game.Point{X: 0, Y: 0}},
+		{name: "Should use the last snapshot after it", now: start.Add(time.Second), expected: game.Point{X: 2, Y: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entities := client.Entities(tt.now)
+			assert.Len(t, entities, 1, "The player of the client shouldn't be interpolated")
+			assert.Equal(t, tt.expected, entities[0].Position)
+		})
+	}
+	assert.Equal(t, []LaserState{{ID: bot, Position: game.Point{X: 1, Y: 1}}}, client.Lasers())
+}
+
+func TestServerAcknowledgesInputs(t *testing.T) {
+	player := game.NewPlayer("Remote", game.DifficultyNormal)
+	engine, err := game.NewEngine(game.SetMap(mapNetplay.Copy()), game.SetPlayers(player))
+	assert.Nil(t, err)
+	defer engine.Stop()
+	server := NewServer(engine)
+	client := &remoteClient{}
+
+	// The engine is busy so the shoot of the input is dropped, but the
+	// movement is still waiting
+	for len(engine.ActionChan) < cap(engine.ActionChan)-1 {
+		engine.Submit(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionNone, CreatedAt: time.Now()})
+	}
+	server.submit(player.ID, client, Input{Sequence: 1, Move: game.DirectionRight, Fire: game.DirectionUp})
+	assert.Zero(t, client.lastAcknowledged(), "The input shouldn't be acknowledged before the movement")
+
+	for len(engine.ActionChan) > 0 {
+		engine.Perform(<-engine.ActionChan)
+	}
+	assert.Equal(t, uint32(1), client.lastAcknowledged())
+}
+
+func TestServerAcknowledgesDiscardedInputs(t *testing.T) {
+	player := game.NewPlayer("Remote", game.DifficultyNormal)
+	engine, err := game.NewEngine(
+		game.SetMap(mapNetplay.Copy()),
+		game.SetPlayers(player),
+		game.SetInputPolicy(game.InputPolicy{Drop: game.DropOldest}),
+	)
+	assert.Nil(t, err)
+	defer engine.Stop()
+	server := NewServer(engine)
+	client := &remoteClient{}
+
+	// The busy engine drops the oldest input to make room for the new one
+	server.submit(player.ID, client, Input{Sequence: 1, Move: game.DirectionRight})
+	for len(engine.ActionChan) < cap(engine.ActionChan) {
+		engine.Submit(&game.MoveAction{EntityID: player.ID, Direction: game.DirectionRight, CreatedAt: time.Now()})
+	}
+	server.submit(player.ID, client, Input{Sequence: 2, Move: game.DirectionRight})
+	assert.Equal(t, 1, engine.InputCounters().Dropped)
+	assert.Equal(t, uint32(1), client.lastAcknowledged())
+	for len(engine.ActionChan) > 0 {
+		<-engine.ActionChan
+	}
+
+	// The inputs rejected while the game is paused are done too
+	engine.Pause()
+	server.submit(player.ID, client, Input{Sequence: 3, Move: game.DirectionRight, Fire: game.DirectionUp})
+	for len(engine.ActionChan) > 0 {
+		engine.Perform(<-engine.ActionChan)
+	}
+	assert.Equal(t, map[error]int{game.ErrPaused: 2}, engine.Rejected())
+	assert.Equal(t, uint32(3), client.lastAcknowledged())
+}
@@ -0,0 +1,212 @@
+package netplay
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// Server keeps the authoritative game engine and sends his state to the
+// connected clients, each client controls one player entity
+type Server struct {
+	Engine  *game.Engine
+	mutex   sync.Mutex
+	clients map[uuid.UUID]*remoteClient
+	tick    uint64
+}
+
+// remoteClient is a client connected to the server
+type remoteClient struct {
+	conn  Conn
+	mutex sync.Mutex
+	// acknowledged is the sequence of the last input processed
+	acknowledged uint32
+	// encoder builds the snapshots sent as deltas over the last snapshot
+	// received by the client
+	encoder DeltaEncoder
+}
+
+// NewServer will build a new server for the given engine, the engine should
+// be started to process the inputs of the clients
+func NewServer(engine *game.Engine) *Server {
+	return &Server{
+		Engine:  engine,
+		clients: make(map[uuid.UUID]*remoteClient),
+	}
+}
+
+// Join will attach the given connection to the player with the given id, the
+// inputs received are submitted to the engine on behalf of the player
+func (s *Server) Join(entityID uuid.UUID, conn Conn) {
+	client := &remoteClient{conn: conn}
+	s.mutex.Lock()
+	s.clients[entityID] = client
+	s.mutex.Unlock()
+	go s.listen(entityID, client)
+}
+
+// Leave will close the connection of the player with the given id
+func (s *Server) Leave(entityID uuid.UUID) {
+	s.mutex.Lock()
+	client, exists := s.clients[entityID]
+	delete(s.clients, entityID)
+	s.mutex.Unlock()
+	if exists {
+		client.conn.Close()
+	}
+}
+
+// listen will submit the inputs received from the given client until the
+// connection is closed
+func (s *Server) listen(entityID uuid.UUID, client *remoteClient) {
+	for {
+		select {
+		case <-client.conn.Done():
+			s.Leave(entityID)
+			return
+		case message := <-client.conn.Receive():
+			switch message := message.(type) {
+			case Input:
+				s.submit(entityID, client, message)
+			case SnapshotAck:
+				client.encoder.Acknowledge(message.Tick)
+			}
+		}
+	}
+}
+
+// submit will send the given input to the engine, the movement and the shoot
+// are separated actions and the input is acknowledged once the engine is done
+// with all of them. The actions dropped by a busy engine are discarded, so
+// they count as done
+func (s *Server) submit(entityID uuid.UUID, client *remoteClient, input Input) {
+	now := time.Now()
+	var actions []game.Action
+	if input.Move != game.DirectionNone {
+		actions = append(actions, &game.MoveAction{EntityID: entityID, Direction: input.Move, CreatedAt: now})
+	}
+	if input.Fire != game.DirectionNone {
+		actions = append(actions, &game.FireAction{ShooterID: entityID, Direction: input.Fire, CreatedAt: now})
+	}
+	if len(actions) == 0 {
+		client.acknowledge(input.Sequence)
+		return
+	}
+	pending := &pendingInput{client: client, sequence: input.Sequence, actions: int32(len(actions))}
+	for _, action := range actions {
+		s.Engine.Submit(&remoteAction{Action: action, input: pending})
+	}
+}
+
+// pendingInput keeps how many actions of an input the engine didn't perform
+// or reject yet
+type pendingInput struct {
+	client   *remoteClient
+	sequence uint32
+	actions  int32
+}
+
+// done will count one action of the input as finished, the input is
+// acknowledged with the last one
+func (p *pendingInput) done() {
+	if atomic.AddInt32(&p.actions, -1) == 0 {
+		p.client.acknowledge(p.sequence)
+	}
+}
+
+// acknowledge will record the given sequence as processed, the inputs arrive
+// in order so the sequence never goes back
+func (c *remoteClient) acknowledge(sequence uint32) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if sequence > c.acknowledged {
+		c.acknowledged = sequence
+	}
+}
+
+// lastAcknowledged returns the sequence of the last input processed
+func (c *remoteClient) lastAcknowledged() uint32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.acknowledged
+}
+
+// remoteAction is an action received from a client, once the engine
+// processes it the action is done even if it is dropped or rejected, so the
+// client stops predicting his input once all the actions of the input are done
+type remoteAction struct {
+	game.Action
+	input *pendingInput
+}
+
+// Discard will discard the action, the dropped or rejected action is done
+func (r *remoteAction) Discard(e *game.Engine) {
+	if d, ok := r.Action.(game.Discarder); ok {
+		d.Discard(e)
+	}
+	r.input.done()
+}
+
+// Perform will perform the action and then it will be done, so the state
+// never misses an acknowledged input
+func (r *remoteAction) Perform(e *game.Engine) {
+	r.Action.Perform(e)
+	r.input.done()
+}
+
+// Snapshot returns the current state of the engine, the entities and the
+// lasers are sorted by his id so the same state gives the same snapshot
+func (s *Server) Snapshot() Snapshot {
+	s.mutex.Lock()
+	s.tick++
+	snapshot := Snapshot{Tick: s.tick}
+	s.mutex.Unlock()
+	s.Engine.RangeEntities(func(entity game.Entity) bool {
+		snapshot.Entities = append(snapshot.Entities, entityState(entity))
+		return true
+	})
+	s.Engine.Lasers.Range(func(id interface{}, la interface{}) bool {
+		laser := la.(game.Laser)
+		snapshot.Lasers = append(snapshot.Lasers, LaserState{ID: laser.ID, Position: laser.Position})
+		return true
+	})
+	snapshot.sort()
+	return snapshot
+}
+
+// Broadcast will send the current state to every client, each one with the
+// sequence of his last input processed. The sequences are taken before the
+// state so the state always includes the acknowledged inputs. The state is
+// sent as a delta over the last snapshot acknowledged by each client
+func (s *Server) Broadcast() {
+	s.mutex.Lock()
+	acknowledged := make(map[*remoteClient]uint32, len(s.clients))
+	for _, client := range s.clients {
+		acknowledged[client] = client.lastAcknowledged()
+	}
+	s.mutex.Unlock()
+	snapshot := s.Snapshot()
+	for client, sequence := range acknowledged {
+		personal := snapshot
+		personal.Acknowledged = sequence
+		client.conn.Send(client.encoder.Encode(personal))
+	}
+}
+
+// Run will broadcast the state on each interval until the given channel is
+// closed
+func (s *Server) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Broadcast()
+		}
+	}
+}
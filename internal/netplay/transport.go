@@ -0,0 +1,129 @@
+package netplay
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// connBuffer is how many messages can wait on each way of a local connection
+const connBuffer = 1024
+
+// ErrClosed is returned when a message is sent on a closed connection
+var ErrClosed = errors.New("connection closed")
+
+// Conn is one end of a connection between a client and the server, the
+// messages are delivered in the same order they were sent
+type Conn interface {
+	Send(message interface{}) error
+	// Receive returns the channel where the messages sent by the other end
+	// arrive
+	Receive() <-chan interface{}
+	// Done returns a channel closed once the connection is closed by any of
+	// the ends
+	Done() <-chan struct{}
+	Close() error
+}
+
+// link keeps the state shared by both ends of a local connection
+type link struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+// LocalConn is an in process connection that delivers each message after a
+// fixed latency, so the network play can be tried without a network
+type LocalConn struct {
+	latency  time.Duration
+	link     *link
+	peer     *LocalConn
+	incoming chan interface{}
+	outgoing chan delayedMessage
+}
+
+// delayedMessage is a message waiting for his latency before being delivered
+type delayedMessage struct {
+	message   interface{}
+	deliverAt time.Time
+}
+
+// Pipe returns both ends of an in process connection, the messages take the
+// given latency on each way
+func Pipe(latency time.Duration) (*LocalConn, *LocalConn) {
+	l := &link{closed: make(chan struct{})}
+	client := &LocalConn{
+		latency:  latency,
+		link:     l,
+		incoming: make(chan interface{}, connBuffer),
+		outgoing: make(chan delayedMessage, connBuffer),
+	}
+	server := &LocalConn{
+		latency:  latency,
+		link:     l,
+		incoming: make(chan interface{}, connBuffer),
+		outgoing: make(chan delayedMessage, connBuffer),
+	}
+	client.peer, server.peer = server, client
+	go client.deliver()
+	go server.deliver()
+	return client, server
+}
+
+// Send will deliver the given message to the other end once the latency is
+// over
+func (c *LocalConn) Send(message interface{}) error {
+	select {
+	case <-c.link.closed:
+		return ErrClosed
+	default:
+	}
+	select {
+	case c.outgoing <- delayedMessage{message: message, deliverAt: time.Now().Add(c.latency)}:
+		return nil
+	case <-c.link.closed:
+		return ErrClosed
+	}
+}
+
+// Receive returns the channel where the messages of the other end arrive
+func (c *LocalConn) Receive() <-chan interface{} {
+	return c.incoming
+}
+
+// Done returns a channel closed once the connection is closed
+func (c *LocalConn) Done() <-chan struct{} {
+	return c.link.closed
+}
+
+// Close will close both ends of the connection, the messages on the way are
+// lost
+func (c *LocalConn) Close() error {
+	c.link.once.Do(func() {
+		close(c.link.closed)
+	})
+	return nil
+}
+
+// deliver will move the sent messages to the other end when their latency is
+// over, the latency is the same for all the messages so the order is kept
+func (c *LocalConn) deliver() {
+	for {
+		select {
+		case <-c.link.closed:
+			return
+		case delayed := <-c.outgoing:
+			timer := time.NewTimer(time.Until(delayed.deliverAt))
+			select {
+			case <-c.link.closed:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			select {
+			case c.peer.incoming <- delayed.message:
+			case <-c.link.closed:
+				return
+			}
+		}
+	}
+}
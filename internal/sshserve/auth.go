@@ -0,0 +1,89 @@
+package sshserve
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Auth keeps who is allowed to join the matches served over ssh, a player
+// joins with any of the authorized keys or with the password
+type Auth struct {
+	// AuthorizedKeys keeps the public keys allowed to join
+	AuthorizedKeys []ssh.PublicKey
+	// Password is shared by all the players, empty when there is no password
+	Password string
+	// NoAuth lets anyone join without authentication, it should be asked for
+	// explicitly since the server is open to anyone reaching it
+	NoAuth bool
+}
+
+// config returns the ssh server config for the auth, there should be a way to
+// authenticate unless the auth is explicitly disabled
+func (a Auth) config() (*ssh.ServerConfig, error) {
+	if a.NoAuth {
+		return &ssh.ServerConfig{NoClientAuth: true}, nil
+	}
+	if len(a.AuthorizedKeys) == 0 && a.Password == "" {
+		return nil, fmt.Errorf("The server needs authorized keys or a password, unless the authentication is disabled")
+	}
+	config := &ssh.ServerConfig{}
+	if len(a.AuthorizedKeys) > 0 {
+		config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			for _, authorized := range a.AuthorizedKeys {
+				if bytes.Equal(authorized.Marshal(), key.Marshal()) {
+					return nil, nil
+				}
+			}
+			return nil, fmt.Errorf("The key of %s is not authorized", conn.User())
+		}
+	}
+	if a.Password != "" {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if subtle.ConstantTimeCompare(password, []byte(a.Password)) == 1 {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("Wrong password for %s", conn.User())
+		}
+	}
+	return config, nil
+}
+
+// LoadAuthorizedKeys returns the public keys on the given file, the file has
+// the format of the OpenSSH authorized_keys files
+func LoadAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []ssh.PublicKey
+	for number, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("Wrong key on the line %d of %s: %v", number+1, path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// LoadPassword returns the password kept on the first line of the given file
+func LoadPassword(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	password := strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r")
+	if password == "" {
+		return "", fmt.Errorf("The password file %s is empty", path)
+	}
+	return password, nil
+}
@@ -0,0 +1,107 @@
+package sshserve
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// newSigner returns a new random ed25519 key
+func newSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	assert.Nil(t, err)
+	return signer
+}
+
+func TestServerAuth(t *testing.T) {
+	authorized, unknown := newSigner(t), newSigner(t)
+	server, err := NewServer(newSigner(t), Auth{
+		AuthorizedKeys: []ssh.PublicKey{authorized.PublicKey()},
+		Password:       "secret",
+	}, newTestSettings(), testLevel)
+	assert.Nil(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go server.Serve(listener)
+
+	tests := []struct {
+		name    string
+		auth    []ssh.AuthMethod
+		allowed bool
+	}{
+		{
+			name:    "Should allow the authorized keys",
+			auth:    []ssh.AuthMethod{ssh.PublicKeys(authorized)},
+			allowed: true,
+		},
+		{
+			name:    "Should allow the password",
+			auth:    []ssh.AuthMethod{ssh.Password("secret")},
+			allowed: true,
+		},
+		{
+			name: "Shouldn't allow the unknown keys",
+			auth: []ssh.AuthMethod{ssh.PublicKeys(unknown)},
+		},
+		{
+			name: "Shouldn't allow a wrong password",
+			auth: []ssh.AuthMethod{ssh.Password("guess")},
+		},
+		{
+			name: "Shouldn't allow the users without authentication",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+				User:            "alice",
+				Auth:            tt.auth,
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			})
+			if client != nil {
+				client.Close()
+			}
+			assert.Equal(t, tt.allowed, err == nil)
+		})
+	}
+}
+
+func TestServerNeedsAuth(t *testing.T) {
+	_, err := NewServer(newSigner(t), Auth{}, newTestSettings(), testLevel)
+	assert.EqualError(t, err, "The server needs authorized keys or a password, unless the authentication is disabled")
+}
+
+func TestLoadAuthorizedKeys(t *testing.T) {
+	first, second := newSigner(t), newSigner(t)
+	path := filepath.Join(t.TempDir(), "authorized_keys")
+	data := "# The players\n" + string(ssh.MarshalAuthorizedKey(first.PublicKey())) + "\n" + string(ssh.MarshalAuthorizedKey(second.PublicKey()))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(data), 0600))
+	keys, err := LoadAuthorizedKeys(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []ssh.PublicKey{first.PublicKey(), second.PublicKey()}, keys)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("ssh-ed25519 wrong\n"), 0600))
+	_, err = LoadAuthorizedKeys(path)
+	assert.Error(t, err)
+}
+
+func TestLoadPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("secret\n"), 0600))
+	password, err := LoadPassword(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", password)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("\n"), 0600))
+	_, err = LoadPassword(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package sshserve
+
+import (
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+)
+
+// escapeKeys keeps the escape sequences sent by the terminals for the special
+// keys the game uses, without the escape byte. Some terminals send the arrows
+// as ESC O instead of ESC [
+var escapeKeys = map[string]tcell.Key{
+	"[A":   tcell.KeyUp,
+	"[B":   tcell.KeyDown,
+	"[C":   tcell.KeyRight,
+	"[D":   tcell.KeyLeft,
+	"OA":   tcell.KeyUp,
+	"OB":   tcell.KeyDown,
+	"OC":   tcell.KeyRight,
+	"OD":   tcell.KeyLeft,
+	"[H":   tcell.KeyHome,
+	"[F":   tcell.KeyEnd,
+	"[Z":   tcell.KeyBacktab,
+	"[3~":  tcell.KeyDelete,
+	"OP":   tcell.KeyF1,
+	"OQ":   tcell.KeyF2,
+	"OR":   tcell.KeyF3,
+	"OS":   tcell.KeyF4,
+	"[13~": tcell.KeyF3,
+}
+
+// parseKeys returns the key events typed on the given bytes read from the
+// terminal of the player. A lonely escape byte is the escape key, the escape
+// sequences not known are ignored
+func parseKeys(input []byte) []*tcell.EventKey {
+	var events []*tcell.EventKey
+	for len(input) > 0 {
+		switch b := input[0]; {
+		case b == 0x1b && len(input) == 1:
+			events = append(events, tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+			input = input[1:]
+		case b == 0x1b:
+			length := escapeLength(input[1:])
+			if key, exists := escapeKeys[string(input[1:1+length])]; exists {
+				events = append(events, tcell.NewEventKey(key, 0, tcell.ModNone))
+			}
+			input = input[1+length:]
+		case b == '\r' || b == '\n':
+			events = append(events, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+			input = input[1:]
+		case b == '\t' || b == 0x7f:
+			events = append(events, tcell.NewEventKey(tcell.Key(b), 0, tcell.ModNone))
+			input = input[1:]
+		case b < ' ':
+			events = append(events, tcell.NewEventKey(tcell.Key(b), 0, tcell.ModCtrl))
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r != utf8.RuneError {
+				events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+			input = input[size:]
+		}
+	}
+	return events
+}
+
+// escapeLength returns the length of the escape sequence at the start of the
+// given bytes, without the escape byte. The CSI sequences end on a byte from
+// @ to ~ and the rest take a single byte after the introducer
+func escapeLength(sequence []byte) int {
+	if len(sequence) < 2 || (sequence[0] != '[' && sequence[0] != 'O') {
+		return 1
+	}
+	if sequence[0] == 'O' {
+		return 2
+	}
+	for i := 1; i < len(sequence); i++ {
+		if sequence[i] >= '@' && sequence[i] <= '~' {
+			return i + 1
+		}
+	}
+	return len(sequence)
+}
@@ -0,0 +1,62 @@
+package sshserve
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/stretchr/testify/assert"
+)
+
+// typedKey is the key and the rune of a key event
+type typedKey struct {
+	Key  tcell.Key
+	Rune rune
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []typedKey
+	}{
+		{
+			name:     "Should parse the runes",
+			input:    "wañ",
+			expected: []typedKey{{tcell.KeyRune, 'w'}, {tcell.KeyRune, 'a'}, {tcell.KeyRune, 'ñ'}},
+		},
+		{
+			name:     "Should parse the arrows on both forms",
+			input:    "\x1b[A\x1bOD",
+			expected: []typedKey{{tcell.KeyUp, 0}, {tcell.KeyLeft, 0}},
+		},
+		{
+			name:     "Should parse the special keys",
+			input:    "\t\x1b[Z\r\x1bOR\x1b[13~",
+			expected: []typedKey{{tcell.KeyTab, 0}, {tcell.KeyBacktab, 0}, {tcell.KeyEnter, 0}, {tcell.KeyF3, 0}, {tcell.KeyF3, 0}},
+		},
+		{
+			name:     "Should parse the control keys",
+			input:    "\x03",
+			expected: []typedKey{{tcell.KeyCtrlC, 0}},
+		},
+		{
+			name:     "Should parse a lonely escape as the escape key",
+			input:    "\x1b",
+			expected: []typedKey{{tcell.KeyEsc, 0}},
+		},
+		{
+			name:     "Should ignore the unknown escape sequences",
+			input:    "\x1b[200~p",
+			expected: []typedKey{{tcell.KeyRune, 'p'}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []typedKey
+			for _, event := range parseKeys([]byte(tt.input)) {
+				keys = append(keys, typedKey{event.Key(), event.Rune()})
+			}
+			assert.Equal(t, tt.expected, keys)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package sshserve
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// enterScreen switches to the alternate screen, so the terminal of the
+	// player is restored once the session is over, and hides the cursor
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	exitScreen  = "\x1b[0m\x1b[?25h\x1b[?1049l"
+)
+
+// channelScreen is a tcell screen that writes to a ssh channel instead of the
+// local terminal. The cells are kept by a simulation screen and each time the
+// screen is shown the cells changed since the last time are written as ANSI
+// escape sequences
+type channelScreen struct {
+	tcell.SimulationScreen
+	out   io.Writer
+	mutex sync.Mutex
+	// shown keeps the cells written on the last frame, nil for a full redraw
+	shown  []tcell.SimCell
+	width  int
+	height int
+	closed bool
+}
+
+// newChannelScreen will build a new screen of the given size writing to the
+// given writer, it should be initialized before using it
+func newChannelScreen(out io.Writer, width int, height int) *channelScreen {
+	return &channelScreen{
+		SimulationScreen: tcell.NewSimulationScreen("UTF-8"),
+		out:              out,
+		width:            width,
+		height:           height,
+	}
+}
+
+// Init will initialize the cells and will prepare the terminal of the player
+func (s *channelScreen) Init() error {
+	if err := s.SimulationScreen.Init(); err != nil {
+		return err
+	}
+	s.SimulationScreen.SetSize(s.width, s.height)
+	_, err := io.WriteString(s.out, enterScreen)
+	return err
+}
+
+// Fini will restore the terminal of the player, the screen can't be shown
+// anymore
+func (s *channelScreen) Fini() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.SimulationScreen.Fini()
+	io.WriteString(s.out, exitScreen)
+}
+
+// Show will write the cells changed since the last frame
+func (s *channelScreen) Show() {
+	s.SimulationScreen.Show()
+	s.flush()
+}
+
+// Sync will write all the cells again
+func (s *channelScreen) Sync() {
+	s.SimulationScreen.Sync()
+	s.mutex.Lock()
+	s.shown = nil
+	s.mutex.Unlock()
+	s.flush()
+}
+
+// setSize will resize the screen to the size of the terminal of the player, the
+// next frame is written from scratch
+func (s *channelScreen) setSize(width int, height int) {
+	s.SimulationScreen.SetSize(width, height)
+	s.mutex.Lock()
+	s.shown = nil
+	s.mutex.Unlock()
+}
+
+// flush will write the cells that changed since the last frame, each cell is
+// written with his own position and style
+func (s *channelScreen) flush() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return
+	}
+	cells, width, height := s.GetContents()
+	var frame bytes.Buffer
+	if s.shown == nil || len(s.shown) != len(cells) {
+		frame.WriteString("\x1b[0m\x1b[2J")
+		s.shown = make([]tcell.SimCell, len(cells))
+	}
+	// The cursor is moved only when the changed cells are not consecutive
+	style, cursorX, cursorY := tcell.Style(-1), -1, -1
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*width + x
+			cell := cells[i]
+			if sameCell(s.shown[i], cell) {
+				continue
+			}
+			s.shown[i] = tcell.SimCell{Style: cell.Style, Runes: append([]rune{}, cell.Runes...)}
+			if len(cell.Runes) == 0 {
+				continue
+			}
+			if x != cursorX || y != cursorY {
+				fmt.Fprintf(&frame, "\x1b[%d;%dH", y+1, x+1)
+			}
+			if cell.Style != style {
+				style = cell.Style
+				frame.WriteString(sgr(style))
+			}
+			frame.WriteString(string(cell.Runes))
+			// The wide runes take the next cell too, the simulation screen
+			// leaves it untouched
+			if runewidth.RuneWidth(cell.Runes[0]) == 2 {
+				x++
+			}
+			cursorX, cursorY = x+1, y
+		}
+	}
+	if frame.Len() > 0 {
+		s.out.Write(frame.Bytes())
+	}
+}
+
+// sameCell returns true when both cells have the same runes and style
+func sameCell(a tcell.SimCell, b tcell.SimCell) bool {
+	if a.Style != b.Style || len(a.Runes) != len(b.Runes) || a.Runes == nil {
+		return false
+	}
+	for i := range a.Runes {
+		if a.Runes[i] != b.Runes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sgr returns the escape sequence that selects the given style, the colors of
+// the palette use the 256 colors sequences and the rest the true color ones
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	var sequence bytes.Buffer
+	sequence.WriteString("\x1b[0")
+	attributes := []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, ";1"},
+		{tcell.AttrDim, ";2"},
+		{tcell.AttrUnderline, ";4"},
+		{tcell.AttrBlink, ";5"},
+		{tcell.AttrReverse, ";7"},
+	}
+	for _, attribute := range attributes {
+		if attrs&attribute.mask != 0 {
+			sequence.WriteString(attribute.code)
+		}
+	}
+	sequence.WriteString(colorCode(fg, 38))
+	sequence.WriteString(colorCode(bg, 48))
+	sequence.WriteString("m")
+	return sequence.String()
+}
+
+// colorCode returns the parameters of the escape sequence for the given color,
+// 38 for the foreground or 48 for the background. The default color is empty
+func colorCode(color tcell.Color, base int) string {
+	switch {
+	case color == tcell.ColorDefault:
+		return ""
+	case color&tcell.ColorIsRGB == 0 && color >= 0 && color < 256:
+		return fmt.Sprintf(";%d;5;%d", base, color)
+	}
+	r, g, b := color.RGB()
+	if r < 0 {
+		return ""
+	}
+	return fmt.Sprintf(";%d;2;%d;%d;%d", base, r, g, b)
+}
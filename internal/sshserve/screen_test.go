@@ -0,0 +1,40 @@
+package sshserve
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelScreen(t *testing.T) {
+	var out bytes.Buffer
+	screen := newChannelScreen(&out, 3, 1)
+	assert.Nil(t, screen.Init())
+	assert.Equal(t, enterScreen, out.String())
+
+	out.Reset()
+	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.Color234)
+	screen.SetContent(0, 0, 'A', nil, style)
+	screen.Show()
+	assert.Equal(t, "\x1b[0m\x1b[2J\x1b[1;1H\x1b[0;38;5;9;48;5;234mA\x1b[0m  ", out.String(), "The first frame should write every cell")
+
+	out.Reset()
+	screen.Show()
+	assert.Equal(t, "", out.String(), "Nothing should be written without changes")
+
+	screen.SetContent(2, 0, 'B', nil, style.Foreground(tcell.NewRGBColor(255, 128, 0)))
+	screen.Show()
+	assert.Equal(t, "\x1b[1;3H\x1b[0;38;2;255;128;0;48;5;234mB", out.String(), "Only the changed cells should be written")
+
+	out.Reset()
+	screen.setSize(2, 1)
+	screen.Show()
+	assert.Contains(t, out.String(), "\x1b[2J", "The screen should be written from scratch after a resize")
+
+	out.Reset()
+	screen.Fini()
+	screen.Show()
+	assert.Equal(t, exitScreen, out.String(), "Nothing should be written once finished")
+}
@@ -0,0 +1,421 @@
+package sshserve
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/view"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultRoundDelay is how long the end of round modal is shown before
+	// the next round starts
+	DefaultRoundDelay = 5 * time.Second
+	// spectateCommand is the command asked by the ssh sessions watching the
+	// match instead of playing it
+	spectateCommand = "spectate"
+	// roundCheckFrequency is how often the server checks if the round is over
+	roundCheckFrequency = 100 * time.Millisecond
+	// defaultWidth and defaultHeight are the size of the screen when the ssh
+	// client doesn't ask for a terminal
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// playerColors keeps the colors given to the players, one by one, as they
+// join the match
+var playerColors = []tcell.Color{
+	tcell.ColorBlue,
+	tcell.ColorGold,
+	tcell.ColorFuchsia,
+	tcell.ColorLime,
+	tcell.ColorOrange,
+	tcell.ColorAqua,
+	tcell.ColorPink,
+	tcell.ColorWhite,
+}
+
+// teams keeps the teams the players are assigned to, one by one, on the team
+// deathmatch
+var teams = []game.Team{game.TeamRed, game.TeamBlue}
+
+// Server serves the game over ssh, each ssh session plays on his own screen
+// but all of them share the same engine. The engine is started when the first
+// player joins and stopped when the last one leaves, and once a round is over
+// the next one starts with the players connected
+type Server struct {
+	// Levels keeps the levels played one after the other, a lost round
+	// repeats the level
+	Levels []game.Level
+	// Settings keeps the rules, the difficulty and the input policy of the
+	// matches, the players and their names come from the ssh sessions
+	Settings view.Settings
+	// RoundDelay is how long the end of round modal is shown
+	RoundDelay time.Duration
+	config     *ssh.ServerConfig
+	mutex      sync.Mutex
+	engine     *game.Engine
+	level      int
+	// players keeps the players connected and the user interface of each one
+	players map[uuid.UUID]*remotePlayer
+	// spectators keeps the user interfaces watching the match
+	spectators map[*view.UserInterface]bool
+	// joined is how many players joined since the server started, used for
+	// picking the look of the next one
+	joined int
+}
+
+// remotePlayer is a player connected over ssh
+type remotePlayer struct {
+	entity game.Entity
+	ui     *view.UserInterface
+}
+
+// NewServer will build a new server with the given host key playing the given
+// levels, the ssh users allowed by the given auth join the match with his user
+// as player name. There should be one level at least
+func NewServer(hostKey ssh.Signer, auth Auth, settings view.Settings, levels ...game.Level) (*Server, error) {
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("There are no levels to play")
+	}
+	config, err := auth.config()
+	if err != nil {
+		return nil, err
+	}
+	config.AddHostKey(hostKey)
+	return &Server{
+		Levels:     levels,
+		Settings:   settings,
+		RoundDelay: DefaultRoundDelay,
+		config:     config,
+		players:    make(map[uuid.UUID]*remotePlayer),
+		spectators: make(map[*view.UserInterface]bool),
+	}, nil
+}
+
+// ListenAndServe will listen on the given address and will serve the game to
+// every ssh connection
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve will accept the connections of the given listener until it is closed
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// Engine returns the engine of the current round, nil when nobody is playing
+func (s *Server) Engine() *game.Engine {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.engine
+}
+
+// handle will do the ssh handshake on the given connection and will play a
+// game on each session channel opened
+func (s *Server) handle(conn net.Conn) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		log.Printf("Error on the ssh handshake with %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "only session channels are allowed")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			log.Printf("Error accepting the session of %s: %v", serverConn.User(), err)
+			continue
+		}
+		go s.session(serverConn.User(), channel, requests)
+	}
+}
+
+// session will wait for the shell request of the given channel and will play
+// the game on it, or will watch the match when the spectate command is asked
+// instead. The terminal size comes from the pty request and the window changes
+func (s *Server) session(user string, channel ssh.Channel, requests <-chan *ssh.Request) {
+	width, height := defaultWidth, defaultHeight
+	var screen *channelScreen
+	for request := range requests {
+		switch request.Type {
+		case "pty-req":
+			width, height = parsePtyRequest(request.Payload)
+			request.Reply(true, nil)
+		case "window-change":
+			if screen != nil {
+				screen.setSize(parseWindowChange(request.Payload))
+			}
+		case "shell", "exec":
+			watching := request.Type == "exec" && parseCommand(request.Payload) == spectateCommand
+			if screen != nil || (request.Type == "exec" && !watching) {
+				request.Reply(false, nil)
+				continue
+			}
+			request.Reply(true, nil)
+			screen = newChannelScreen(channel, width, height)
+			go func() {
+				s.play(user, channel, screen, watching)
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+				channel.Close()
+			}()
+		default:
+			request.Reply(false, nil)
+		}
+	}
+}
+
+// play will run a user interface on the given screen joined to the shared
+// engine, or watching it, until the player quits or the connection is closed
+func (s *Server) play(user string, channel ssh.Channel, screen *channelScreen, watching bool) {
+	if err := screen.Init(); err != nil {
+		log.Printf("Error starting the screen of %s: %v", user, err)
+		return
+	}
+	ui := view.New(s.Levels...)
+	ui.App.SetScreen(screen)
+	ui.Settings = s.Settings
+	var playerID uuid.UUID
+	var err error
+	if watching {
+		err = s.watch(ui)
+	} else {
+		playerID, err = s.join(user, ui)
+	}
+	if err != nil {
+		screen.Fini()
+		fmt.Fprintf(channel, "%v\r\n", err)
+		return
+	}
+	ui.Start()
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		buffer := make([]byte, 256)
+		for {
+			n, err := channel.Read(buffer)
+			if err != nil {
+				return
+			}
+			for _, event := range parseKeys(buffer[:n]) {
+				screen.PostEvent(event)
+			}
+		}
+	}()
+	select {
+	case <-ui.ErrChan:
+	case <-closed:
+		ui.App.Stop()
+	}
+	if watching {
+		s.unwatch(ui)
+		return
+	}
+	s.leave(playerID)
+}
+
+// join will add a new player with the given name to the shared engine and will
+// attach the given user interface to it, the engine is started for the first
+// player. It returns the id of the new player
+func (s *Server) join(name string, ui *view.UserInterface) (uuid.UUID, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	player := s.newPlayer(name)
+	if s.engine == nil {
+		s.players[player.ID] = &remotePlayer{entity: player, ui: ui}
+		if err := s.startRound(); err != nil {
+			delete(s.players, player.ID)
+			return uuid.Nil, err
+		}
+		return player.ID, nil
+	}
+	if !s.engine.Submit(&game.JoinAction{Player: player}) {
+		return uuid.Nil, fmt.Errorf("The server is too busy, try again later")
+	}
+	s.players[player.ID] = &remotePlayer{entity: player, ui: ui}
+	ui.Join(s.engine, player)
+	return player.ID, nil
+}
+
+// watch will attach the given user interface to the shared engine as a
+// spectator, the spectators follow the match on the next rounds as well
+func (s *Server) watch(ui *view.UserInterface) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.engine == nil {
+		return fmt.Errorf("Nobody is playing right now, try again later")
+	}
+	s.spectators[ui] = true
+	ui.Watch(s.engine)
+	return nil
+}
+
+// unwatch will remove the given spectator
+func (s *Server) unwatch(ui *view.UserInterface) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.spectators, ui)
+}
+
+// leave will remove the player with the given id from the shared engine, the
+// engine is stopped when there is nobody left
+func (s *Server) leave(playerID uuid.UUID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.players, playerID)
+	if s.engine == nil {
+		return
+	}
+	if len(s.players) == 0 {
+		s.engine.Stop()
+		s.engine = nil
+		return
+	}
+	s.engine.Submit(&game.LeaveAction{EntityID: playerID})
+}
+
+// newPlayer will build the player for the given name with the next color and
+// glyph, on the team deathmatch the players are split between the teams
+func (s *Server) newPlayer(name string) game.Entity {
+	player := game.NewPlayer(name, s.Settings.Difficulty)
+	player.Renderable = game.Renderable{
+		Glyph: rune('A' + s.joined%26),
+		Color: fmt.Sprintf("#%06x", playerColors[s.joined%len(playerColors)].Hex()),
+	}
+	if s.Settings.Rules.Mode == game.ModeTeamDeathmatch {
+		player.Team = teams[s.joined%len(teams)]
+	}
+	s.joined++
+	return player
+}
+
+// startRound will start a new engine on the current level with all the players
+// connected, every user interface is attached to it. The levels that can't be
+// played are logged and skipped. It should be called with the mutex locked
+func (s *Server) startRound() error {
+	var engine *game.Engine
+	for tries := 0; engine == nil; tries++ {
+		if tries == len(s.Levels) {
+			return fmt.Errorf("None of the levels can be played")
+		}
+		// The levels could change while the server is running
+		s.level %= len(s.Levels)
+		var err error
+		engine, err = game.NewEngine(
+			game.SetRules(s.Settings.Rules),
+			game.SetDifficulty(s.Settings.Difficulty),
+			game.SetInputPolicy(s.Settings.Input),
+			game.SetLevel(s.Levels[s.level]),
+		)
+		if err != nil {
+			log.Printf("Error starting the level %s: %v", s.Levels[s.level].Name, err)
+			s.level = (s.level + 1) % len(s.Levels)
+		}
+	}
+	// The engine is not started yet, so the players can be added right away
+	for _, player := range s.players {
+		engine.Perform(&game.JoinAction{Player: player.entity})
+	}
+	engine.Start()
+	s.engine = engine
+	for _, player := range s.players {
+		player.ui.Join(engine, player.entity)
+	}
+	for spectator := range s.spectators {
+		spectator.Watch(engine)
+	}
+	go s.watchRound(engine)
+	return nil
+}
+
+// watchRound will wait until the round of the given engine is over and will
+// start the next one after the round delay, the level is repeated when the
+// players lost
+func (s *Server) watchRound(engine *game.Engine) {
+	ticker := time.NewTicker(roundCheckFrequency)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.mutex.Lock()
+		if s.engine != engine {
+			s.mutex.Unlock()
+			return
+		}
+		round := engine.Round()
+		over := round.LevelComplete || round.GameOver
+		s.mutex.Unlock()
+		if over {
+			break
+		}
+	}
+	time.Sleep(s.RoundDelay)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.engine != engine {
+		return
+	}
+	engine.Stop()
+	if engine.Round().LevelComplete {
+		s.level = (s.level + 1) % len(s.Levels)
+	}
+	if err := s.startRound(); err != nil {
+		log.Println("Error starting the next round", err)
+		s.engine = nil
+	}
+}
+
+// parsePtyRequest returns the size of the terminal asked on the payload of a
+// pty request, the default size when the payload is not valid
+func parsePtyRequest(payload []byte) (int, int) {
+	var request struct {
+		Term          string
+		Width, Height uint32
+		PixelWidth    uint32
+		PixelHeight   uint32
+		Modes         string
+	}
+	if err := ssh.Unmarshal(payload, &request); err != nil || request.Width == 0 || request.Height == 0 {
+		return defaultWidth, defaultHeight
+	}
+	return int(request.Width), int(request.Height)
+}
+
+// parseCommand returns the command asked on the payload of an exec request
+func parseCommand(payload []byte) string {
+	var request struct{ Command string }
+	if err := ssh.Unmarshal(payload, &request); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(request.Command)
+}
+
+// parseWindowChange returns the size of the terminal on the payload of a window
+// change request
+func parseWindowChange(payload []byte) (int, int) {
+	if len(payload) < 8 {
+		return defaultWidth, defaultHeight
+	}
+	return int(binary.BigEndian.Uint32(payload)), int(binary.BigEndian.Uint32(payload[4:]))
+}
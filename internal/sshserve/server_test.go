@@ -0,0 +1,213 @@
+package sshserve
+
+import (
+	"bytes"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/view"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+var mapServer = game.Map{
+	[]rune("███████████"),
+	[]rune("█         █"),
+	[]rune("█   1 2   █"),
+	[]rune("█         █"),
+	[]rune("███████████"),
+}
+
+var testLevel = game.Level{Name: "Test", Map: mapServer}
+
+// output keeps everything written by the server on a ssh session
+type output struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.buffer.Write(p)
+}
+
+func (o *output) String() string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.buffer.String()
+}
+
+// newTestSettings returns the settings of a deathmatch served for the tests
+func newTestSettings() view.Settings {
+	return view.Settings{
+		Rules:      game.Rules{Mode: game.ModeDeathmatch, FragLimit: game.DefaultFragLimit},
+		Difficulty: game.DifficultyNormal,
+		Input:      game.DefaultInputPolicy,
+	}
+}
+
+// newTestServer returns a server without authentication listening on a random
+// local port
+func newTestServer(t *testing.T) (*Server, string) {
+	server, err := NewServer(newSigner(t), Auth{NoAuth: true}, newTestSettings(), testLevel)
+	assert.Nil(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go server.Serve(listener)
+	t.Cleanup(func() { listener.Close() })
+	return server, listener.Addr().String()
+}
+
+// connect will open a ssh session with a terminal for the given user, it
+// returns the session and his output
+func connect(t *testing.T, address string, user string) (*ssh.Session, *output) {
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            user,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { client.Close() })
+	session, err := client.NewSession()
+	assert.Nil(t, err)
+	out := &output{}
+	session.Stdout = out
+	assert.Nil(t, session.RequestPty("xterm-256color", 30, 100, ssh.TerminalModes{}))
+	return session, out
+}
+
+// waitFor will check the given condition until it is true or the time is over
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("The condition was never true")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// players returns the names of the players on the given engine
+func players(engine *game.Engine) []string {
+	var names []string
+	for _, player := range engine.Players() {
+		names = append(names, player.Name)
+	}
+	return names
+}
+
+func TestServeSSH(t *testing.T) {
+	server, address := newTestServer(t)
+
+	alice, aliceOut := connect(t, address, "alice")
+	aliceIn, err := alice.StdinPipe()
+	assert.Nil(t, err)
+	assert.Nil(t, alice.Shell())
+	waitFor(t, func() bool { return strings.Contains(aliceOut.String(), "Lives") })
+	engine := server.Engine()
+	waitFor(t, func() bool { return len(players(engine)) == 1 })
+	assert.Equal(t, []string{"alice"}, players(engine))
+
+	bob, bobOut := connect(t, address, "bob")
+	bobIn, err := bob.StdinPipe()
+	assert.Nil(t, err)
+	assert.Nil(t, bob.Shell())
+	waitFor(t, func() bool { return strings.Contains(bobOut.String(), "Lives") })
+	waitFor(t, func() bool { return len(players(engine)) == 2 })
+	assert.Equal(t, engine, server.Engine(), "The players should share the engine")
+
+	var bobPlayer game.Entity
+	for _, player := range engine.Players() {
+		if player.Name == "bob" {
+			bobPlayer = player
+		}
+	}
+	_, err = bobIn.Write([]byte("\x1b[C"))
+	assert.Nil(t, err)
+	waitFor(t, func() bool {
+		player, _ := engine.Entity(bobPlayer.ID)
+		return player.Position == game.Point{X: bobPlayer.Position.X + 1, Y: bobPlayer.Position.Y}
+	})
+
+	_, err = bobIn.Write([]byte{0x03})
+	assert.Nil(t, err)
+	assert.Nil(t, bob.Wait(), "The session should end with a zero status")
+	waitFor(t, func() bool { return len(players(engine)) == 1 })
+	assert.Equal(t, []string{"alice"}, players(engine))
+
+	aliceIn.Write([]byte{0x03})
+	alice.Wait()
+	waitFor(t, func() bool { return server.Engine() == nil })
+}
+
+func TestLoadHostKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "ssh_host_key")
+	generated, err := LoadHostKey(path)
+	assert.Nil(t, err)
+	loaded, err := LoadHostKey(path)
+	assert.Nil(t, err)
+	assert.Equal(t, generated.PublicKey().Marshal(), loaded.PublicKey().Marshal(), "The key should be kept on the file")
+}
+
+func TestServerLevels(t *testing.T) {
+	signer := newSigner(t)
+	_, err := NewServer(signer, Auth{NoAuth: true}, view.Settings{})
+	assert.EqualError(t, err, "There are no levels to play")
+
+	server, err := NewServer(signer, Auth{NoAuth: true}, view.Settings{}, game.Level{Name: "First", Map: mapServer}, game.Level{Name: "Second", Map: mapServer})
+	assert.Nil(t, err)
+	server.players[uuid.Must(uuid.NewV4())] = &remotePlayer{entity: game.NewPlayer("alice", game.DifficultyNormal), ui: view.New()}
+	server.Levels = server.Levels[:1]
+	server.level = 1
+	server.mutex.Lock()
+	assert.Nil(t, server.startRound(), "The level should wrap around the levels left")
+	server.mutex.Unlock()
+	engine := server.Engine()
+	defer engine.Stop()
+	assert.Equal(t, "First", engine.LevelName)
+	assert.Zero(t, server.level)
+}
+
+func TestSpectateSSH(t *testing.T) {
+	server, address := newTestServer(t)
+
+	// There is nothing to watch until someone plays
+	early, earlyOut := connect(t, address, "carol")
+	assert.Nil(t, early.Start(spectateCommand))
+	early.Wait()
+	assert.Contains(t, earlyOut.String(), "Nobody is playing right now")
+
+	alice, aliceOut := connect(t, address, "alice")
+	aliceIn, err := alice.StdinPipe()
+	assert.Nil(t, err)
+	assert.Nil(t, alice.Shell())
+	waitFor(t, func() bool { return strings.Contains(aliceOut.String(), "Lives") })
+
+	carol, carolOut := connect(t, address, "carol")
+	carolIn, err := carol.StdinPipe()
+	assert.Nil(t, err)
+	assert.Nil(t, carol.Start(spectateCommand))
+	waitFor(t, func() bool { return strings.Contains(carolOut.String(), "Spectating") })
+	assert.Equal(t, []string{"alice"}, players(server.Engine()), "The spectator shouldn't join the match")
+
+	_, err = carolIn.Write([]byte{0x03})
+	assert.Nil(t, err)
+	assert.Nil(t, carol.Wait())
+	waitFor(t, func() bool {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		return len(server.spectators) == 0
+	})
+	assert.Equal(t, []string{"alice"}, players(server.Engine()))
+
+	aliceIn.Write([]byte{0x03})
+	alice.Wait()
+}
@@ -0,0 +1,77 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/rivo/tview"
+)
+
+const debugColor = tcell.ColorYellow
+
+// debugOverlay keeps the state of the debug overlay, the frames per second are
+// measured while the overlay is shown
+type debugOverlay struct {
+	enabled    bool
+	frames     int
+	fps        int
+	frameStart time.Time
+}
+
+// toggleDebug will show or hide the debug overlay
+func (ui *UserInterface) toggleDebug() {
+	ui.debug.enabled = !ui.debug.enabled
+	ui.debug.frames = 0
+	ui.debug.fps = 0
+	ui.debug.frameStart = time.Now()
+}
+
+// drawDebug will render the engine internals on the top left corner of the
+// view port and the short id of each entity next to his glyph
+func (ui *UserInterface) drawDebug() drawFunc {
+	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
+		if !ui.debug.enabled {
+			return 0, 0, 0, 0
+		}
+		ui.debug.frames++
+		if elapsed := time.Since(ui.debug.frameStart); elapsed >= time.Second {
+			ui.debug.fps = int(float64(ui.debug.frames) / elapsed.Seconds())
+			ui.debug.frames = 0
+			ui.debug.frameStart = time.Now()
+		}
+		info := ui.Engine.DebugInfo()
+		lines := []string{
+			fmt.Sprintf("FPS        %d", ui.debug.fps),
+			fmt.Sprintf("Tick       %s", info.TickTime),
+			fmt.Sprintf("Actions    %d/%d", info.QueuedActions, info.QueueCapacity),
+			fmt.Sprintf("Dropped    %d", info.Input.Dropped),
+			fmt.Sprintf("Coalesced  %d", info.Input.Coalesced),
+			fmt.Sprintf("Stale      %d", info.Input.Stale),
+			fmt.Sprintf("Rejected   %d", info.Rejected),
+			fmt.Sprintf("Lasers     %d", info.Lasers),
+			fmt.Sprintf("Bots       %d", info.Bots),
+			fmt.Sprintf("Goroutines %d", info.Goroutines),
+		}
+		for i, line := range lines {
+			tview.Print(screen, line, x+1, y+1+i, width-2, tview.AlignLeft, debugColor)
+		}
+
+		centerX, centerY := ui.screenCenter(width, height)
+		ui.Engine.RangeEntities(func(entity game.Entity) bool {
+			if !entity.Health.Eliminated {
+				tview.Print(screen, shortID(entity.ID), centerX+entity.Position.X+1, centerY+entity.Position.Y, 4, tview.AlignLeft, debugColor)
+			}
+			return true
+		})
+		return 0, 0, 0, 0
+	})
+}
+
+// shortID returns the first characters of the given id, enough for tell the
+// entities apart
+func shortID(id uuid.UUID) string {
+	return id.String()[:4]
+}
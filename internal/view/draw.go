@@ -2,6 +2,9 @@ package view
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell"
 	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
@@ -15,8 +18,23 @@ const (
 	laserColor      = tcell.ColorRed
 	textColor       = tcell.ColorWhite
 	botColor        = tcell.ColorMediumAquamarine
+	lifeColor       = tcell.ColorRed
+	blinkFrequency  = 150 * time.Millisecond
+	// bossHealthBarWidth is the amount of cells of the boss health bar
+	bossHealthBarWidth = 40
 )
 
+// tileColors keeps the color used for render each one of the map elements,
+// the elements not listed here are not rendered
+var tileColors = map[game.MapElement]tcell.Color{
+	game.MapElementWall:             wallColor,
+	game.MapElementDestructibleWall: tcell.Color94,
+	game.MapElementSlow:             tcell.Color30,
+	game.MapElementHazard:           tcell.ColorOrangeRed,
+	game.MapElementOneWay:           tcell.ColorYellow,
+	game.MapElementPhaseWall:        tcell.Color61,
+}
+
 type drawFunc func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int)
 type drawCallback func()
 
@@ -39,48 +57,84 @@ func (ui *UserInterface) setupDrawCallbacks(callbacks ...drawCallback) {
 	}
 }
 
-// drawMap will render the game map into your terminal
+// drawMap will render the game map into your terminal, with the fog of war
+// the tiles explored but out of sight are dimmed and the rest are hidden
 func (ui *UserInterface) drawMap() drawFunc {
 	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
 		style := tcell.StyleDefault.Background(backgroundColor)
 		// Re visit this center stuff
-		centerX := width / 2
-		centerY := height / 2
-		for _, wall := range ui.Engine.GameMap.GetMapElements()[game.MapElementWall] {
-			x := centerX + wall.X
-			y := centerY + wall.Y
-			screen.SetContent(x, y, '█', nil, style.Foreground(wallColor))
+		centerX, centerY := ui.screenCenter(width, height)
+		gameMap := ui.Engine.Map()
+		ui.updateFog(gameMap)
+		for element, positions := range gameMap.GetMapElements() {
+			color, visible := tileColors[element]
+			if !visible {
+				continue
+			}
+			for _, tile := range positions {
+				color := color
+				if !ui.isVisible(tile) {
+					if !ui.fog.explored[tile] {
+						continue
+					}
+					color = fogColor
+				}
+				x := centerX + tile.X
+				y := centerY + tile.Y
+				screen.SetContent(x, y, gameMap.RuneAt(tile), nil, style.Foreground(color))
+			}
 		}
 		return 0, 0, 0, 0
 	})
 }
 
-// drawActors will render all the actors involved on the game
-func (ui *UserInterface) drawActors() drawFunc {
+// drawEntities will render all the players and bots involved on the game with
+// his own glyph and color, the ships bigger than one cell are rendered on all
+// of them. The cells out of sight are hidden with the fog of war
+func (ui *UserInterface) drawEntities() drawFunc {
 	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
 		style := tcell.StyleDefault.Background(backgroundColor)
 		// Re visit this center stuff
-		centerX := width / 2
-		centerY := height / 2
-		for _, actor := range ui.Engine.Actors {
-			x := centerX + actor.Position.X
-			y := centerY + actor.Position.Y
+		centerX, centerY := ui.screenCenter(width, height)
+		now := time.Now()
+		ui.Engine.RangeEntities(func(entity game.Entity) bool {
+			// Invulnerable entities blink until they can be damaged again
+			if entity.Health.Eliminated || (entity.IsInvulnerable(now) && now.UnixNano()/int64(blinkFrequency)%2 == 0) {
+				return true
+			}
+			glyph, color := entity.Renderable.Glyph, tcell.GetColor(entity.Renderable.Color)
+			if glyph == 0 {
+				glyph = 'Y'
+			}
+			if color == tcell.ColorDefault {
+				color = botColor
+			}
+			for _, cell := range entity.Cells() {
+				if !ui.isVisible(cell) {
+					continue
+				}
+				x := centerX + cell.X
+				y := centerY + cell.Y
 
-			screen.SetContent(x, y, 'A', nil, style.Foreground(playerColor))
-		}
+				screen.SetContent(x, y, glyph, nil, style.Foreground(color))
+			}
+			return true
+		})
 		return 0, 0, 0, 0
 	})
 }
 
-// drawLasers will render all the active lasers
+// drawLasers will render all the active lasers the local players can see
 func (ui *UserInterface) drawLasers() drawFunc {
 	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
 		style := tcell.StyleDefault.Background(backgroundColor)
 		// Re visit this center stuff
-		centerX := width / 2
-		centerY := height / 2
+		centerX, centerY := ui.screenCenter(width, height)
 		ui.Engine.Lasers.Range(func(laserID interface{}, la interface{}) bool {
 			laser := la.(game.Laser)
+			if !ui.isVisible(laser.Position) {
+				return true
+			}
 			x := centerX + laser.Position.X
 			y := centerY + laser.Position.Y
 
@@ -91,19 +145,42 @@ func (ui *UserInterface) drawLasers() drawFunc {
 	})
 }
 
-// drawBots will render all the active bots
-func (ui *UserInterface) drawBots() drawFunc {
+// drawHUD will render the lives and the life left of each local player on the
+// top left corner of the view port
+func (ui *UserInterface) drawHUD() drawFunc {
 	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
-		style := tcell.StyleDefault.Background(backgroundColor)
-		// Re visit this center stuff
-		centerX := width / 2
-		centerY := height / 2
-		ui.Engine.Bots.Range(func(botID interface{}, value interface{}) bool {
-			bot := value.(game.Bot)
-			x := centerX + bot.Position.X
-			y := centerY + bot.Position.Y
-
-			screen.SetContent(x, y, 'Y', nil, style.Foreground(botColor))
+		hudX := x + 1
+		for _, player := range ui.Players {
+			entity, exists := ui.Engine.Entity(player.EntityID)
+			if !exists {
+				continue
+			}
+			hud := fmt.Sprintf("[#%06x]%c[#%06x] Lives %d %s ", player.Color.Hex(), player.Glyph, lifeColor.Hex(), entity.Health.Lives, strings.Repeat("♥", entity.Health.Life))
+			_, printed := tview.Print(screen, hud, hudX, y, width-hudX, tview.AlignLeft, lifeColor)
+			hudX += printed
+		}
+		status := fmt.Sprintf(" %s ", ui.Engine.Difficulty)
+		if ui.Engine.Rules.Mode == game.ModeSurvival {
+			status = fmt.Sprintf(" Wave %d -%s", ui.Engine.Round().Wave, status)
+		}
+		tview.Print(screen, status, x, y, width-1, tview.AlignRight, textColor)
+		return 0, 0, 0, 0
+	})
+}
+
+// drawBossHealth will render a health bar for each boss on the bottom of the
+// view port
+func (ui *UserInterface) drawBossHealth() drawFunc {
+	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
+		barY := y + height - 2
+		ui.Engine.RangeEntities(func(entity game.Entity) bool {
+			if !entity.IsBoss() {
+				return true
+			}
+			filled := bossHealthBarWidth * entity.Health.Life / entity.Health.MaxLife
+			bar := fmt.Sprintf("BOSS %s%s", strings.Repeat("█", filled), strings.Repeat("░", bossHealthBarWidth-filled))
+			tview.Print(screen, bar, x, barY, width, tview.AlignCenter, tcell.GetColor(entity.Renderable.Color))
+			barY--
 			return true
 		})
 		return 0, 0, 0, 0
@@ -118,50 +195,106 @@ func (ui *UserInterface) setupScore() drawCallback {
 	ui.pages.AddPage("score", modal, true, false)
 	return func() {
 		var text string
-		for actorID, actor := range ui.Engine.Actors {
-			score := ui.Engine.Score[actorID]
-			text += fmt.Sprintf("%s - %d\n", actor.Name, score)
+		round := ui.Engine.Round()
+		for _, player := range ui.rankedPlayers() {
+			score := round.Score[player.ID]
+			text += fmt.Sprintf("%s - %d - lives %d", player.Name, score, player.Health.Lives)
+			if ui.Engine.Rules.Mode != game.ModeCampaign {
+				text += fmt.Sprintf(" - frags %d - team %s", round.Frags[player.ID], player.Team)
+			}
+			text += "\n"
 		}
 		tv.SetText(text)
 	}
 }
 
+// rankedPlayers returns the players sorted by his score, from the highest to
+// the lowest one
+func (ui *UserInterface) rankedPlayers() []game.Entity {
+	players := ui.Engine.Players()
+	score := ui.Engine.Round().Score
+	sort.Slice(players, func(i, j int) bool {
+		if score[players[i].ID] == score[players[j].ID] {
+			return players[i].Name < players[j].Name
+		}
+		return score[players[i].ID] > score[players[j].ID]
+	})
+	return players
+}
+
 // setupLevelComplete will render a final modal showing the name of the winning
-// player
+// player, from there the player can go to the next level or retry this one
 func (ui *UserInterface) setupLevelComplete() drawCallback {
-	tv := tview.NewTextView()
-	tv.SetTextAlign(tview.AlignCenter).
-		SetScrollable(true).
-		SetBorder(true).
-		SetBackgroundColor(backgroundColor).
-		SetTitle("Level complete")
-	modal := centeredModal(tv)
+	modal := ui.endOfRoundModal("Level complete", []string{"Next level", "Retry", "Menu"}, map[string]func(){
+		"Next level": func() {
+			if ui.playtesting {
+				ui.showEditor()
+				return
+			}
+			if ui.spectating && ui.level+1 < len(ui.Levels) {
+				ui.spectate(ui.level + 1)
+				return
+			}
+			if ui.level+1 < len(ui.Levels) {
+				ui.startLevel(ui.level + 1)
+				return
+			}
+			ui.showMenu()
+		},
+		"Retry": ui.retry,
+		"Menu":  ui.showMenu,
+	})
 	ui.pages.AddPage("levelComplete", modal, true, false)
 	return func() {
-		if ui.Engine.LevelComplete {
-			ui.pages.ShowPage("levelComplete")
-			player := ui.Engine.Actors[ui.Engine.RoundWinner]
-			text := fmt.Sprintf("\nCongratulations %s you are the winner!!\n\n", player.Name)
-			tv.SetText(text)
+		round := ui.Engine.Round()
+		if round.LevelComplete && !ui.roundOver && !ui.shared {
+			ui.roundOver = true
+			if !ui.playtesting && !ui.spectating && ui.level+1 < len(ui.Levels) && ui.unlockedLevel <= ui.level {
+				ui.unlockedLevel = ui.level + 1
+			}
+			player, _ := ui.Engine.Entity(round.RoundWinner)
+			text := fmt.Sprintf("Congratulations %s you are the winner!!", player.Name)
+			if ui.Engine.Rules.Mode == game.ModeTeamDeathmatch {
+				text = fmt.Sprintf("Team %s wins!!\n\n%s made the most frags", round.WinningTeam, player.Name)
+			}
+			if !ui.playtesting && ui.level+1 == len(ui.Levels) {
+				text += "\n\nYou completed all the levels"
+			}
+			modal.SetText(text + ui.matchSummary())
+			ui.recordHighScores(func() {
+				ui.pages.ShowPage("levelComplete")
+				ui.App.SetFocus(modal)
+			})
 		}
 	}
 }
 
-// setupGameOver will render a final modal when the main player dies
+// setupGameOver will render a final modal when the main player dies, from
+// there the player can retry the level
 func (ui *UserInterface) setupGameOver() drawCallback {
-	tv := tview.NewTextView()
-	tv.SetTextAlign(tview.AlignCenter).
-		SetScrollable(true).
-		SetBorder(true).
-		SetBackgroundColor(backgroundColor).
-		SetTitle("GAME OVER")
-	modal := centeredModal(tv)
+	modal := ui.endOfRoundModal("GAME OVER", []string{"Retry", "Menu", "Quit"}, map[string]func(){
+		"Retry": ui.retry,
+		"Menu":  ui.showMenu,
+		"Quit":  ui.quit,
+	})
 	ui.pages.AddPage("gameOver", modal, true, false)
 	return func() {
-		if ui.Engine.GameOver {
-			ui.pages.ShowPage("gameOver")
-			text := "\nThis is the end of your adventure, try again\n\n"
-			tv.SetText(text)
+		round := ui.Engine.Round()
+		if round.GameOver && !ui.roundOver && !ui.shared {
+			ui.roundOver = true
+			text := "This is the end of your adventure, try again"
+			switch {
+			case ui.Engine.Rules.Mode == game.ModeSurvival:
+				text = fmt.Sprintf("You survived %d waves with %d points", round.WavesSurvived, round.Score[ui.MainPlayerID])
+			case len(ui.Players) > 1:
+				player, _ := ui.Engine.Entity(round.RoundWinner)
+				text = fmt.Sprintf("Everyone is down, %s made the highest score", player.Name)
+			}
+			modal.SetText(text + ui.matchSummary())
+			ui.recordHighScores(func() {
+				ui.pages.ShowPage("gameOver")
+				ui.App.SetFocus(modal)
+			})
 		}
 	}
 }
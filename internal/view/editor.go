@@ -0,0 +1,369 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/rivo/tview"
+)
+
+const (
+	// editorWidth and editorHeight are the size of the new maps
+	editorWidth  = 40
+	editorHeight = 20
+	// editorMinSize is the smallest width and height a map can have
+	editorMinSize = 3
+	// editorHistory is how many changes can be undone
+	editorHistory = 100
+	// editorFile is the file proposed when the map was never saved
+	editorFile = "level.map"
+	// editorHelp lists the keys of the level editor, it takes two lines on the
+	// small terminals
+	editorHelp = "← → ↑ ↓ move - space paint - x paint while moving - tab brush - u r undo redo - [ ] width - - + height - n new - p playtest - ctrl+s save - ctrl+o load - esc menu"
+)
+
+// editorBrush is a tile that can be painted on the level editor
+type editorBrush struct {
+	Glyph rune
+	Name  string
+}
+
+// editorBrushes keeps all the tiles that can be painted, in the order they
+// are selected with the tab key
+var editorBrushes = []editorBrush{
+	{Glyph: '█', Name: "wall"},
+	{Glyph: '▓', Name: "destructible wall"},
+	{Glyph: '#', Name: "phase wall"},
+	{Glyph: '~', Name: "slow"},
+	{Glyph: '*', Name: "hazard"},
+	{Glyph: '↑', Name: "one way up"},
+	{Glyph: '↓', Name: "one way down"},
+	{Glyph: '←', Name: "one way left"},
+	{Glyph: '→', Name: "one way right"},
+	{Glyph: 'S', Name: "bot spawn"},
+	{Glyph: '1', Name: "player 1 spawn"},
+	{Glyph: '2', Name: "player 2 spawn"},
+	{Glyph: '3', Name: "player 3 spawn"},
+	{Glyph: '4', Name: "player 4 spawn"},
+	{Glyph: ' ', Name: "empty"},
+}
+
+// levelEditor keeps the map is being edited and the changes that can be
+// undone and redone
+type levelEditor struct {
+	gameMap game.Map
+	// cursor is the column and the row of the map where the brush paints
+	cursor game.Point
+	brush  int
+	// painting is the flag that determines when the brush paints each tile
+	// the cursor moves over
+	painting bool
+	undo     []game.Map
+	redo     []game.Map
+	// path is the file where the map was saved or loaded for last time
+	path string
+	// status keeps the result of the last save, load or playtest
+	status string
+	box    *tview.Box
+}
+
+// size returns the width and the height of the map
+func (le *levelEditor) size() (int, int) {
+	if len(le.gameMap) == 0 {
+		return 0, 0
+	}
+	return len(le.gameMap[0]), len(le.gameMap)
+}
+
+// change will replace the map by the given one keeping the current map on the
+// undo history, the redo history is lost after a new change
+func (le *levelEditor) change(m game.Map) {
+	le.undo = append(le.undo, le.gameMap)
+	if len(le.undo) > editorHistory {
+		le.undo = le.undo[1:]
+	}
+	le.redo = nil
+	le.gameMap = m
+	le.clampCursor()
+}
+
+// undoChange will go back to the map before the last change
+func (le *levelEditor) undoChange() {
+	if len(le.undo) == 0 {
+		return
+	}
+	le.redo = append(le.redo, le.gameMap)
+	le.gameMap = le.undo[len(le.undo)-1]
+	le.undo = le.undo[:len(le.undo)-1]
+	le.clampCursor()
+}
+
+// redoChange will apply again the last change undone
+func (le *levelEditor) redoChange() {
+	if len(le.redo) == 0 {
+		return
+	}
+	le.undo = append(le.undo, le.gameMap)
+	le.gameMap = le.redo[len(le.redo)-1]
+	le.redo = le.redo[:len(le.redo)-1]
+	le.clampCursor()
+}
+
+// paint will place the current brush under the cursor, painting the same glyph
+// twice is not a change
+func (le *levelEditor) paint() {
+	glyph := editorBrushes[le.brush].Glyph
+	if le.gameMap[le.cursor.Y][le.cursor.X] == glyph {
+		return
+	}
+	m := le.gameMap.Copy()
+	m[le.cursor.Y][le.cursor.X] = glyph
+	le.change(m)
+}
+
+// resize will grow or shrink the map by the given columns and rows, the map
+// can't be smaller than the minimum size
+func (le *levelEditor) resize(columns int, rows int) {
+	width, height := le.size()
+	if width+columns < editorMinSize || height+rows < editorMinSize {
+		return
+	}
+	le.change(le.gameMap.Resize(width+columns, height+rows))
+}
+
+// moveCursor will move the cursor one tile on the given direction without
+// leave the map, if the editor is painting the new tile is painted
+func (le *levelEditor) moveCursor(direction game.Direction) {
+	switch direction {
+	case game.DirectionUp:
+		le.cursor.Y--
+	case game.DirectionDown:
+		le.cursor.Y++
+	case game.DirectionLeft:
+		le.cursor.X--
+	case game.DirectionRight:
+		le.cursor.X++
+	}
+	le.clampCursor()
+	if le.painting {
+		le.paint()
+	}
+}
+
+// clampCursor will bring the cursor back inside the map
+func (le *levelEditor) clampCursor() {
+	width, height := le.size()
+	if le.cursor.X >= width {
+		le.cursor.X = width - 1
+	}
+	if le.cursor.Y >= height {
+		le.cursor.Y = height - 1
+	}
+	if le.cursor.X < 0 {
+		le.cursor.X = 0
+	}
+	if le.cursor.Y < 0 {
+		le.cursor.Y = 0
+	}
+}
+
+// setupEditor will add to the pages the level editor, it starts with an empty
+// map
+func (ui *UserInterface) setupEditor() {
+	box := tview.NewBox().
+		SetBorder(true).
+		SetTitle("Level editor").
+		SetBackgroundColor(backgroundColor)
+	box.SetDrawFunc(ui.drawEditor())
+	box.SetInputCapture(ui.editorInput)
+	helpText := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetText(editorHelp).
+		SetTextColor(textColor)
+	helpText.SetBackgroundColor(backgroundColor)
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(box, 0, 1, true).
+		AddItem(helpText, 2, 1, false)
+	ui.pages.AddPage("editor", flex, true, false)
+	ui.editor = levelEditor{
+		gameMap: game.NewMap(editorWidth, editorHeight),
+		cursor:  game.Point{X: editorWidth / 2, Y: editorHeight / 2},
+		box:     box,
+	}
+}
+
+// showEditor will bring the level editor to the front, the game of the
+// playtest, if any, is over
+func (ui *UserInterface) showEditor() {
+	if ui.playtesting {
+		ui.Engine.Stop()
+		ui.Engine = nil
+		ui.playtesting = false
+	}
+	ui.pages.SwitchToPage("editor")
+	ui.App.SetFocus(ui.editor.box)
+}
+
+// playtest will start a game on the map of the level editor, there is a bot
+// shooting and moving on each spawn position. The map needs a player spawn
+// for each local player when it has any
+func (ui *UserInterface) playtest() {
+	m := ui.editor.gameMap.Copy()
+	if spawns := len(m.PlayerSpawns()); spawns > 0 && spawns < ui.Settings.Players {
+		ui.editor.status = fmt.Sprintf("The map needs %d player spawns", ui.Settings.Players)
+		return
+	}
+	var bots []game.BotSpawn
+	for range m.GetMapElements()[game.MapElementSpawn] {
+		bots = append(bots, game.BotSpawn{Strategy: game.ShootAndMoveStrategy})
+	}
+	ui.playtesting = true
+	ui.spectating = false
+	ui.play(game.Level{Name: "Playtest", Map: m, Bots: bots})
+}
+
+// editorInput will apply the keys pressed on the level editor
+func (ui *UserInterface) editorInput(event *tcell.EventKey) *tcell.EventKey {
+	le := &ui.editor
+	switch event.Key() {
+	case tcell.KeyUp:
+		le.moveCursor(game.DirectionUp)
+	case tcell.KeyDown:
+		le.moveCursor(game.DirectionDown)
+	case tcell.KeyLeft:
+		le.moveCursor(game.DirectionLeft)
+	case tcell.KeyRight:
+		le.moveCursor(game.DirectionRight)
+	case tcell.KeyEnter:
+		le.paint()
+	case tcell.KeyTab:
+		le.brush = (le.brush + 1) % len(editorBrushes)
+	case tcell.KeyBacktab:
+		le.brush = (le.brush + len(editorBrushes) - 1) % len(editorBrushes)
+	case tcell.KeyCtrlZ:
+		le.undoChange()
+	case tcell.KeyCtrlY:
+		le.redoChange()
+	case tcell.KeyCtrlS:
+		ui.showEditorFile("Save map", func(path string) error {
+			return game.SaveMap(path, le.gameMap)
+		})
+	case tcell.KeyCtrlO:
+		ui.showEditorFile("Load map", func(path string) error {
+			m, err := game.LoadMap(path)
+			if err != nil {
+				return err
+			}
+			// The ragged maps are filled until they are a rectangle, and the
+			// maps can't be smaller than the editor allows
+			m, err = m.Rectangle(editorMinSize)
+			if err != nil {
+				return err
+			}
+			le.change(m)
+			return nil
+		})
+	case tcell.KeyEsc:
+		ui.pages.SwitchToPage("menu")
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case ' ':
+			le.paint()
+		case 'x':
+			le.painting = !le.painting
+			if le.painting {
+				le.paint()
+			}
+		case 'u':
+			le.undoChange()
+		case 'r':
+			le.redoChange()
+		case '[':
+			le.resize(-1, 0)
+		case ']':
+			le.resize(1, 0)
+		case '-':
+			le.resize(0, -1)
+		case '+', '=':
+			le.resize(0, 1)
+		case 'n':
+			le.change(game.NewMap(editorWidth, editorHeight))
+		case 'p':
+			ui.playtest()
+		}
+	}
+	return nil
+}
+
+// showEditorFile will show a form asking for the file where the given action
+// is applied, the action is the save or the load of the map
+func (ui *UserInterface) showEditorFile(title string, action func(path string) error) {
+	le := &ui.editor
+	path := le.path
+	if path == "" {
+		path = editorFile
+	}
+	form := tview.NewForm()
+	form.AddInputField("File", path, 40, nil, func(text string) {
+		path = text
+	})
+	closeForm := func() {
+		ui.pages.RemovePage("editorFile")
+		ui.App.SetFocus(le.box)
+	}
+	form.AddButton("Ok", func() {
+		if err := action(path); err != nil {
+			form.SetTitle(fmt.Sprintf("%s: %v", title, err))
+			return
+		}
+		le.path = path
+		le.status = fmt.Sprintf("%s %s", title, path)
+		closeForm()
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetBorder(true).SetTitle(title).SetBackgroundColor(backgroundColor)
+	ui.pages.RemovePage("editorFile")
+	ui.pages.AddPage("editorFile", centeredBox(form, 60, 7), true, true)
+	ui.App.SetFocus(form)
+}
+
+// drawEditor will render the map of the level editor with the cursor, the
+// spawn positions are rendered as well, and a status line with the brush, the
+// size of the map and the cursor position
+func (ui *UserInterface) drawEditor() drawFunc {
+	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
+		le := &ui.editor
+		style := tcell.StyleDefault.Background(backgroundColor)
+		centerX := width / 2
+		centerY := height / 2
+		for element, positions := range le.gameMap.GetMapElements() {
+			color, visible := tileColors[element]
+			if !visible {
+				color = textColor
+			}
+			for _, tile := range positions {
+				screen.SetContent(centerX+tile.X, centerY+tile.Y, le.gameMap.RuneAt(tile), nil, style.Foreground(color))
+			}
+		}
+		// The map center is the origin of the positions of the map elements
+		mapWidth, mapHeight := le.size()
+		cursorX := centerX - mapWidth/2 + le.cursor.X
+		cursorY := centerY - mapHeight/2 + le.cursor.Y
+		glyph := le.gameMap[le.cursor.Y][le.cursor.X]
+		screen.SetContent(cursorX, cursorY, glyph, nil, style.Reverse(true))
+
+		brush := editorBrushes[le.brush]
+		status := fmt.Sprintf("Brush %c %s - %dx%d - %d,%d", brush.Glyph, brush.Name, mapWidth, mapHeight, le.cursor.X, le.cursor.Y)
+		if le.painting {
+			status += " - painting"
+		}
+		if le.status != "" {
+			status += " - " + le.status
+		}
+		tview.Print(screen, tview.Escape(status), x+1, y+height-2, width-2, tview.AlignLeft, textColor)
+		return 0, 0, 0, 0
+	})
+}
@@ -0,0 +1,44 @@
+package view
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// fogColor is the color of the explored tiles that are out of sight
+const fogColor = tcell.Color238
+
+// fogOfWar keeps the tiles the local players see right now and all the tiles
+// they have seen since the level started
+type fogOfWar struct {
+	visible  map[game.Point]bool
+	explored map[game.Point]bool
+}
+
+// updateFog will find the tiles of the given map on the line of sight of any
+// local player, the screen is shared so each player sees what the others see
+func (ui *UserInterface) updateFog(gameMap game.Map) {
+	if !ui.Engine.Rules.FogOfWar || ui.spectating {
+		return
+	}
+	if ui.fog.explored == nil {
+		ui.fog.explored = make(map[game.Point]bool)
+	}
+	ui.fog.visible = make(map[game.Point]bool)
+	for _, player := range ui.Players {
+		entity, exists := ui.Engine.Entity(player.EntityID)
+		if !exists {
+			continue
+		}
+		for position := range gameMap.VisibleFrom(entity.Position, game.VisionRadius) {
+			ui.fog.visible[position] = true
+			ui.fog.explored[position] = true
+		}
+	}
+}
+
+// isVisible returns true when the given position is seen by the local players,
+// without the fog of war or while spectating everything is visible
+func (ui *UserInterface) isVisible(p game.Point) bool {
+	return !ui.Engine.Rules.FogOfWar || ui.spectating || ui.fog.visible[p]
+}
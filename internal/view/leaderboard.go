@@ -0,0 +1,97 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/rivo/tview"
+)
+
+// setupLeaderboard will add to the pages the screen with the high scores
+func (ui *UserInterface) setupLeaderboard() {
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	text.SetBorder(true).SetTitle("Leaderboard").SetBackgroundColor(backgroundColor)
+	text.SetDoneFunc(func(key tcell.Key) {
+		ui.pages.SwitchToPage("menu")
+	})
+	ui.pages.AddPage("leaderboard", centeredBox(text, 60, 24), true, false)
+	ui.leaderboardView = text
+}
+
+// showLeaderboard will refresh the high scores of all the boards and will
+// bring them to the front
+func (ui *UserInterface) showLeaderboard() {
+	var text strings.Builder
+	if ui.Leaderboard == nil || len(ui.Leaderboard.Boards()) == 0 {
+		text.WriteString("There are no high scores yet, go and play!")
+	} else {
+		for _, board := range ui.Leaderboard.Boards() {
+			fmt.Fprintf(&text, "[yellow]%s - %s - %s[white]\n", board.Map, board.Mode, board.Difficulty)
+			for position, score := range ui.Leaderboard.Top(board) {
+				fmt.Fprintf(&text, "%2d. %-20s %7d %8s %s\n", position+1, tview.Escape(score.Name), score.Score, score.Duration, score.Date.Format("2006-01-02"))
+			}
+			text.WriteString("\n")
+		}
+	}
+	ui.leaderboardView.SetText(text.String()).ScrollToBeginning()
+	ui.pages.SwitchToPage("leaderboard")
+	ui.App.SetFocus(ui.leaderboardView)
+}
+
+// recordHighScores will show a form for write the name of each local player
+// with a new high score, once the scores are saved or if there is no new high
+// score the given function is called. The playtests never record high scores
+func (ui *UserInterface) recordHighScores(next func()) {
+	if ui.Leaderboard == nil || ui.playtesting || ui.spectating {
+		next()
+		return
+	}
+	board := ui.Engine.Board()
+	var highScores []game.HighScore
+	for _, player := range ui.Players {
+		highScore := ui.Engine.NewHighScore(player.EntityID)
+		if ui.Leaderboard.Qualifies(board, highScore.Score) {
+			highScores = append(highScores, highScore)
+		}
+	}
+	if len(highScores) == 0 {
+		next()
+		return
+	}
+	form := tview.NewForm()
+	for i := range highScores {
+		index := i
+		label := fmt.Sprintf("%d points", highScores[index].Score)
+		form.AddInputField(label, highScores[index].Name, 20, nil, func(text string) {
+			highScores[index].Name = text
+		})
+	}
+	added := false
+	form.AddButton("Save", func() {
+		// The scores are added once even when the file can't be saved
+		if !added {
+			for _, highScore := range highScores {
+				ui.Leaderboard.Add(highScore)
+			}
+			added = true
+		}
+		if err := ui.Leaderboard.Save(); err != nil {
+			form.SetTitle(fmt.Sprintf("Can't save the high scores: %v", err))
+			return
+		}
+		ui.pages.RemovePage("highScore")
+		next()
+	})
+	form.AddButton("Skip", func() {
+		ui.pages.RemovePage("highScore")
+		next()
+	})
+	form.SetBorder(true).SetTitle("New high score!").SetBackgroundColor(backgroundColor)
+	ui.pages.RemovePage("highScore")
+	ui.pages.AddPage("highScore", centeredBox(form, 50, 5+2*len(highScores)), true, true)
+	ui.App.SetFocus(form)
+}
@@ -0,0 +1,210 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/rivo/tview"
+)
+
+const title = `
+ ___                        _    _
+/ __| _ __  __ _  __  ___  | |_ (_) _ __
+\__ \| '_ \/ _' |/ _|/ -_) (_-< | || '_ \
+|___/| .__/\__,_|\__|\___| /__/_|_|| .__/
+     |_|    S H O O T E R          |_|
+`
+
+// setupMenu will add to the pages the main menu, the level select and the
+// leaderboard screens
+func (ui *UserInterface) setupMenu() {
+	ui.setupMainMenu()
+	ui.setupLevelSelect()
+	ui.setupLeaderboard()
+	ui.setupEditor()
+}
+
+// showMenu will pause the current game, if any, and bring the main menu to the
+// front, while playtesting the level editor comes back instead. There is no
+// menu for the shared engines
+func (ui *UserInterface) showMenu() {
+	if ui.shared {
+		return
+	}
+	if ui.playtesting {
+		ui.showEditor()
+		return
+	}
+	if ui.Engine != nil {
+		ui.Engine.Pause()
+	}
+	ui.pages.SwitchToPage("menu")
+}
+
+// setupMainMenu will render the title screen with the main options of the game
+func (ui *UserInterface) setupMainMenu() {
+	titleView := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(playerColor).
+		SetText(title)
+	titleView.SetBackgroundColor(backgroundColor)
+
+	list := tview.NewList().
+		AddItem("New game", "Start the campaign from the first level", 'n', func() {
+			ui.startLevel(0)
+		}).
+		AddItem("Continue", "Resume the game or start the last unlocked level", 'c', func() {
+			if ui.Engine != nil && !ui.roundOver {
+				ui.showGame()
+				return
+			}
+			ui.startLevel(ui.unlockedLevel)
+		}).
+		AddItem("Level select", "Play any of the unlocked levels", 'l', func() {
+			ui.showLevelSelect()
+		}).
+		AddItem("Leaderboard", "Check the high scores", 'h', func() {
+			ui.showLeaderboard()
+		}).
+		AddItem("Spectate", "Watch the last level played with every ship on autopilot", 'w', func() {
+			ui.spectate(ui.level)
+		}).
+		AddItem("Level editor", "Draw your own maps and try them", 'e', func() {
+			ui.showEditor()
+		}).
+		AddItem("Settings", "Change your preferences", 's', func() {
+			ui.showSettings()
+		}).
+		AddItem("Quit", "Exit the game", 'q', func() {
+			ui.quit()
+		})
+	list.SetBorder(true).SetTitle("Menu").SetBackgroundColor(backgroundColor)
+
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(titleView, 7, 1, false).
+		AddItem(list, 0, 1, true)
+	ui.pages.AddPage("menu", centeredBox(flex, 50, 26), true, false)
+}
+
+// setupLevelSelect will render the list of levels, the locked ones can't be
+// selected
+func (ui *UserInterface) setupLevelSelect() {
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle("Level select").SetBackgroundColor(backgroundColor)
+	list.SetDoneFunc(func() {
+		ui.pages.SwitchToPage("menu")
+	})
+	ui.pages.AddPage("levels", centeredBox(list, 50, 20), true, false)
+	ui.levelList = list
+}
+
+// showLevelSelect will refresh the level list with the unlocked levels and
+// will bring it to the front
+func (ui *UserInterface) showLevelSelect() {
+	ui.levelList.Clear()
+	for i := range ui.Levels {
+		level := i
+		status := "Unlocked"
+		if level > ui.unlockedLevel {
+			status = "Locked"
+		}
+		ui.levelList.AddItem(fmt.Sprintf("%d. %s", level+1, ui.Levels[level].Name), status, 0, func() {
+			if level <= ui.unlockedLevel {
+				ui.startLevel(level)
+			}
+		})
+	}
+	ui.pages.SwitchToPage("levels")
+	ui.App.SetFocus(ui.levelList)
+}
+
+// showSettings will render a form for change the user preferences, the form is
+// built each time for show the current settings
+func (ui *UserInterface) showSettings() {
+	form := tview.NewForm()
+	players := make([]string, len(DefaultPlayers))
+	for i := range players {
+		players[i] = fmt.Sprint(i + 1)
+	}
+	form.AddDropDown("Players", players, ui.Settings.Players-1, func(option string, optionIndex int) {
+		ui.Settings.Players = optionIndex + 1
+	})
+	modes := make([]string, len(game.GameModes))
+	for i, mode := range game.GameModes {
+		modes[i] = mode.String()
+	}
+	form.AddDropDown("Mode", modes, int(ui.Settings.Rules.Mode), func(option string, optionIndex int) {
+		ui.Settings.Rules.Mode = game.GameMode(optionIndex)
+	})
+	difficulties := make([]string, len(game.Difficulties))
+	current := 0
+	for i, difficulty := range game.Difficulties {
+		difficulties[i] = difficulty.String()
+		if difficulty == ui.Settings.Difficulty {
+			current = i
+		}
+	}
+	form.AddDropDown("Difficulty", difficulties, current, func(option string, optionIndex int) {
+		ui.Settings.Difficulty = game.Difficulties[optionIndex]
+	})
+	form.AddCheckbox("Friendly fire", ui.Settings.Rules.FriendlyFire, func(checked bool) {
+		ui.Settings.Rules.FriendlyFire = checked
+	})
+	form.AddCheckbox("Fog of war", ui.Settings.Rules.FogOfWar, func(checked bool) {
+		ui.Settings.Rules.FogOfWar = checked
+	})
+	form.AddInputField("Frag limit", fmt.Sprint(ui.Settings.Rules.FragLimit), 5, tview.InputFieldInteger, func(text string) {
+		ui.Settings.Rules.FragLimit, _ = strconv.Atoi(text)
+	})
+	for i := range DefaultPlayers {
+		index := i
+		form.AddInputField(fmt.Sprintf("Player %d name", index+1), ui.Settings.playerName(index), 20, nil, func(text string) {
+			for len(ui.Settings.PlayerNames) <= index {
+				ui.Settings.PlayerNames = append(ui.Settings.PlayerNames, "")
+			}
+			ui.Settings.PlayerNames[index] = text
+		})
+	}
+	form.AddButton("Back", func() {
+		ui.pages.SwitchToPage("menu")
+	})
+	form.SetBorder(true).SetTitle("Settings").SetBackgroundColor(backgroundColor)
+	form.SetCancelFunc(func() {
+		ui.pages.SwitchToPage("menu")
+	})
+	ui.pages.RemovePage("settings")
+	ui.pages.AddPage("settings", centeredBox(form, 50, 20), true, false)
+	ui.pages.SwitchToPage("settings")
+}
+
+// centeredBox will place the given primitive on the center of the screen with
+// a fixed size
+func centeredBox(p tview.Primitive, width int, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// endOfRoundModal will build a modal with the given buttons, each button is
+// linked to the function with the same label
+func (ui *UserInterface) endOfRoundModal(title string, buttons []string, actions map[string]func()) *tview.Modal {
+	modal := tview.NewModal().
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if action, exists := actions[buttonLabel]; exists {
+				action()
+			}
+		})
+	modal.SetBackgroundColor(backgroundColor).
+		SetBorderColor(textColor).
+		SetTitle(title)
+	modal.SetTextColor(tcell.ColorWhite)
+	return modal
+}
@@ -0,0 +1,122 @@
+package view
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// Key identifies a key of the keyboard, the special keys such as the arrows
+// are identified by his tcell key and the rest by his rune
+type Key struct {
+	Key  tcell.Key
+	Rune rune
+}
+
+// keyFromEvent will build the key pressed on the given event
+func keyFromEvent(event *tcell.EventKey) Key {
+	if event.Key() == tcell.KeyRune {
+		return Key{Key: tcell.KeyRune, Rune: event.Rune()}
+	}
+	return Key{Key: event.Key()}
+}
+
+// runeKey is a shortcut for build the key linked to the given rune
+func runeKey(r rune) Key {
+	return Key{Key: tcell.KeyRune, Rune: r}
+}
+
+// KeyBindings keeps the keys used by a player for move his spaceship and shoot
+// the laser on each direction
+type KeyBindings struct {
+	Move  map[Key]game.Direction
+	Shoot map[Key]game.Direction
+}
+
+// Player keeps the information needed for render and control one of the local
+// players sharing the keyboard
+type Player struct {
+	EntityID uuid.UUID
+	Keys     KeyBindings
+	Color    tcell.Color
+	Glyph    rune
+}
+
+// DefaultPlayers keeps the key bindings, the color and the glyph used for each
+// local player, the first player uses the arrows and wasd, the second one ijkl
+// and tfgh, the third one the numpad 8456 and excv and the fourth one [;'/ and
+// ybnu
+var DefaultPlayers = []Player{
+	{
+		Keys: KeyBindings{
+			Move: map[Key]game.Direction{
+				{Key: tcell.KeyUp}:    game.DirectionUp,
+				{Key: tcell.KeyDown}:  game.DirectionDown,
+				{Key: tcell.KeyLeft}:  game.DirectionLeft,
+				{Key: tcell.KeyRight}: game.DirectionRight,
+			},
+			Shoot: map[Key]game.Direction{
+				runeKey('w'): game.DirectionUp,
+				runeKey('s'): game.DirectionDown,
+				runeKey('a'): game.DirectionLeft,
+				runeKey('d'): game.DirectionRight,
+			},
+		},
+		Color: playerColor,
+		Glyph: 'A',
+	},
+	{
+		Keys: KeyBindings{
+			Move: map[Key]game.Direction{
+				runeKey('i'): game.DirectionUp,
+				runeKey('k'): game.DirectionDown,
+				runeKey('j'): game.DirectionLeft,
+				runeKey('l'): game.DirectionRight,
+			},
+			Shoot: map[Key]game.Direction{
+				runeKey('t'): game.DirectionUp,
+				runeKey('g'): game.DirectionDown,
+				runeKey('f'): game.DirectionLeft,
+				runeKey('h'): game.DirectionRight,
+			},
+		},
+		Color: tcell.ColorGold,
+		Glyph: 'B',
+	},
+	{
+		Keys: KeyBindings{
+			Move: map[Key]game.Direction{
+				runeKey('8'): game.DirectionUp,
+				runeKey('5'): game.DirectionDown,
+				runeKey('4'): game.DirectionLeft,
+				runeKey('6'): game.DirectionRight,
+			},
+			Shoot: map[Key]game.Direction{
+				runeKey('e'): game.DirectionUp,
+				runeKey('c'): game.DirectionDown,
+				runeKey('x'): game.DirectionLeft,
+				runeKey('v'): game.DirectionRight,
+			},
+		},
+		Color: tcell.ColorFuchsia,
+		Glyph: 'C',
+	},
+	{
+		Keys: KeyBindings{
+			Move: map[Key]game.Direction{
+				runeKey('['):  game.DirectionUp,
+				runeKey('/'):  game.DirectionDown,
+				runeKey(';'):  game.DirectionLeft,
+				runeKey('\''): game.DirectionRight,
+			},
+			Shoot: map[Key]game.Direction{
+				runeKey('y'): game.DirectionUp,
+				runeKey('n'): game.DirectionDown,
+				runeKey('b'): game.DirectionLeft,
+				runeKey('u'): game.DirectionRight,
+			},
+		},
+		Color: tcell.ColorLime,
+		Glyph: 'D',
+	},
+}
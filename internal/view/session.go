@@ -0,0 +1,107 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// sharedEngine is an engine shared with other user interfaces and the player
+// moved from this one, there is no player when the engine is only watched
+type sharedEngine struct {
+	engine   *game.Engine
+	player   game.Entity
+	watching bool
+}
+
+// Join will attach the user interface to an engine shared with other user
+// interfaces, as the matches served over ssh. The given player is moved with
+// the keys of the first local player. The shared engine is never paused nor
+// stopped from here, and there is no menu, the match goes on until the one
+// running the engine starts the next round. Join can be called from any
+// goroutine, the engine is attached on the next frame
+func (ui *UserInterface) Join(engine *game.Engine, player game.Entity) {
+	ui.joinMutex.Lock()
+	defer ui.joinMutex.Unlock()
+	ui.joining = &sharedEngine{engine: engine, player: player}
+}
+
+// Watch will attach the user interface to an engine shared with other user
+// interfaces as a spectator, the camera follows the ships or moves freely and
+// the whole map is shown even with the fog of war. As Join, it can be called
+// from any goroutine
+func (ui *UserInterface) Watch(engine *game.Engine) {
+	ui.joinMutex.Lock()
+	defer ui.joinMutex.Unlock()
+	ui.joining = &sharedEngine{engine: engine, watching: true}
+}
+
+// attachJoined will attach the engine given on the last call to Join or Watch,
+// if any
+func (ui *UserInterface) attachJoined() {
+	ui.joinMutex.Lock()
+	joining := ui.joining
+	ui.joining = nil
+	ui.joinMutex.Unlock()
+	if joining == nil {
+		return
+	}
+	ui.shared = true
+	ui.playtesting = false
+	ui.spectating = joining.watching
+	ui.Engine = joining.engine
+	ui.fog = fogOfWar{}
+	ui.roundOver = false
+	ui.pages.SwitchToPage("viewport")
+	ui.App.SetFocus(ui.viewPort)
+	if joining.watching {
+		ui.Players = nil
+		ui.MainPlayerID = uuid.Nil
+		ui.spectator = spectator{}
+		ui.followNext(1)
+		return
+	}
+	local := DefaultPlayers[0]
+	local.EntityID = joining.player.ID
+	local.Glyph = joining.player.Renderable.Glyph
+	if color := tcell.GetColor(joining.player.Renderable.Color); color != tcell.ColorDefault {
+		local.Color = color
+	}
+	ui.Players = []Player{local}
+	ui.MainPlayerID = joining.player.ID
+}
+
+// setupSharedRoundOver will render a modal when the round of a shared engine
+// is over, the players wait there for the next round
+func (ui *UserInterface) setupSharedRoundOver() drawCallback {
+	modal := ui.endOfRoundModal("Round over", []string{"Quit"}, map[string]func(){
+		"Quit": ui.quit,
+	})
+	ui.pages.AddPage("roundOver", modal, true, false)
+	return func() {
+		if !ui.shared || ui.roundOver {
+			return
+		}
+		round := ui.Engine.Round()
+		if !round.LevelComplete && !round.GameOver {
+			return
+		}
+		ui.roundOver = true
+		// The winner could have left the match already
+		player, exists := ui.Engine.Entity(round.RoundWinner)
+		text := "The round is over"
+		switch {
+		case ui.Engine.Rules.Mode == game.ModeTeamDeathmatch && round.LevelComplete:
+			text = fmt.Sprintf("Team %s wins!!", round.WinningTeam)
+		case exists && round.GameOver:
+			text = fmt.Sprintf("Everyone is down, %s made the highest score", player.Name)
+		case exists:
+			text = fmt.Sprintf("%s is the winner!!", player.Name)
+		}
+		modal.SetText(text + ui.matchSummary() + "\n\nThe next round starts soon")
+		ui.pages.ShowPage("roundOver")
+		ui.App.SetFocus(modal)
+	}
+}
@@ -0,0 +1,154 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell"
+	"github.com/gofrs/uuid"
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+	"github.com/rivo/tview"
+)
+
+// spectatorHelp lists the keys of the spectator mode
+const spectatorHelp = "tab / shift+tab follow next - ← → ↑ ↓ free camera - p score - m menu"
+
+// spectator keeps the camera of the spectator mode, the camera follows an
+// entity or it moves freely over the map
+type spectator struct {
+	// following is the id of the entity followed by the camera, when it is nil
+	// the camera is free
+	following uuid.UUID
+	// camera is the position of the map shown on the center of the view port
+	camera game.Point
+}
+
+// spectate will start a match on the level with the given index where every
+// local player is on autopilot, so the match can be watched
+func (ui *UserInterface) spectate(level int) {
+	ui.level = level
+	ui.playtesting = false
+	ui.spectating = true
+	ui.spectator = spectator{}
+	ui.play(ui.Levels[level])
+	ui.spectator.following = ui.MainPlayerID
+}
+
+// watchedEntities returns the entities the camera can follow, the players go
+// first and then the bots, each group sorted by his name
+func (ui *UserInterface) watchedEntities() []game.Entity {
+	var entities []game.Entity
+	ui.Engine.RangeEntities(func(entity game.Entity) bool {
+		if !entity.Health.Eliminated {
+			entities = append(entities, entity)
+		}
+		return true
+	})
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].IsPlayer() != entities[j].IsPlayer() {
+			return entities[i].IsPlayer()
+		}
+		if entities[i].Name == entities[j].Name {
+			return entities[i].ID.String() < entities[j].ID.String()
+		}
+		return entities[i].Name < entities[j].Name
+	})
+	return entities
+}
+
+// followNext will move the camera to the next entity, or to the previous one
+// with a negative step, the free camera follows the first entity
+func (ui *UserInterface) followNext(step int) {
+	entities := ui.watchedEntities()
+	if len(entities) == 0 {
+		return
+	}
+	next := 0
+	for i, entity := range entities {
+		if entity.ID == ui.spectator.following {
+			next = (i + step + len(entities)) % len(entities)
+			break
+		}
+	}
+	ui.spectator.following = entities[next].ID
+}
+
+// spectatorInput will apply the keys pressed while spectating, the arrows
+// release the camera from the followed entity
+func (ui *UserInterface) spectatorInput(event *tcell.EventKey) {
+	var offset game.Point
+	switch event.Key() {
+	case tcell.KeyTab:
+		ui.followNext(1)
+	case tcell.KeyBacktab:
+		ui.followNext(-1)
+	case tcell.KeyUp:
+		offset.Y = -1
+	case tcell.KeyDown:
+		offset.Y = 1
+	case tcell.KeyLeft:
+		offset.X = -1
+	case tcell.KeyRight:
+		offset.X = 1
+	}
+	if offset != (game.Point{}) {
+		ui.spectator.camera = ui.cameraPosition().Add(offset)
+		ui.spectator.following = uuid.Nil
+	}
+}
+
+// cameraPosition returns the position of the map shown on the center of the
+// view port, it is always the map center unless the user is spectating. The
+// camera stays on the last position of the followed entity once it is gone
+func (ui *UserInterface) cameraPosition() game.Point {
+	if !ui.spectating {
+		return game.Point{}
+	}
+	if entity, exists := ui.Engine.Entity(ui.spectator.following); exists {
+		ui.spectator.camera = entity.Position
+	}
+	return ui.spectator.camera
+}
+
+// screenCenter returns where the origin of the map is rendered on a view port
+// of the given size
+func (ui *UserInterface) screenCenter(width int, height int) (int, int) {
+	camera := ui.cameraPosition()
+	return width/2 - camera.X, height/2 - camera.Y
+}
+
+// drawSpectator will render the entity followed by the camera and the stats
+// of everyone on the match while spectating
+func (ui *UserInterface) drawSpectator() drawFunc {
+	return drawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
+		if !ui.spectating {
+			return 0, 0, 0, 0
+		}
+		status := "Spectating - free camera"
+		if entity, exists := ui.Engine.Entity(ui.spectator.following); exists {
+			status = fmt.Sprintf("Spectating - following %s", entity.Name)
+		}
+		tview.Print(screen, tview.Escape(status+" - "+spectatorHelp), x+1, y+1, width-2, tview.AlignLeft, textColor)
+		lineY := y + 2
+		round := ui.Engine.Round()
+		for _, entity := range ui.watchedEntities() {
+			if lineY >= y+height-1 {
+				break
+			}
+			line := fmt.Sprintf("%s ♥%d lives %d", entity.Name, entity.Health.Life, entity.Health.Lives)
+			if entity.IsPlayer() {
+				line += fmt.Sprintf(" score %d", round.Score[entity.ID])
+				if ui.Engine.Rules.Mode != game.ModeCampaign {
+					line += fmt.Sprintf(" frags %d", round.Frags[entity.ID])
+				}
+			}
+			color := textColor
+			if entity.ID == ui.spectator.following {
+				color = debugColor
+			}
+			tview.Print(screen, tview.Escape(line), x+1, lineY, width-2, tview.AlignRight, color)
+			lineY++
+		}
+		return 0, 0, 0, 0
+	})
+}
@@ -0,0 +1,43 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ramonmacias/go-spaceship-shooter/internal/game"
+)
+
+// matchSummary returns the stats of the local players for the end of round
+// modal, the stats are exported to the StatsOut file too
+func (ui *UserInterface) matchSummary() string {
+	stats := ui.Engine.MatchStats()
+	var summary strings.Builder
+	summary.WriteString("\n")
+	if stats.TimeToClear > 0 {
+		fmt.Fprintf(&summary, "\nCleared in %s", stats.TimeToClear)
+	}
+	for _, player := range stats.Players {
+		if !ui.isLocalPlayer(player) {
+			continue
+		}
+		fmt.Fprintf(&summary, "\n%s: %d/%d hits (%.0f%%), %d kills, %d damage taken, %d moves",
+			player.Name, player.Hits, player.ShotsFired, player.Accuracy*100, player.TotalKills(), player.DamageTaken, player.Distance)
+	}
+	if ui.StatsOut != "" {
+		if err := game.WriteMatchStats(ui.StatsOut, stats); err != nil {
+			fmt.Fprintf(&summary, "\n\nCan't export the stats: %v", err)
+		}
+	}
+	return summary.String()
+}
+
+// isLocalPlayer returns true when the given stats belong to one of the local
+// players
+func (ui *UserInterface) isLocalPlayer(stats game.PlayerStats) bool {
+	for _, player := range ui.Players {
+		if player.EntityID == stats.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package view
 
 import (
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -14,6 +16,26 @@ const (
 	drawFrequency = 17 * time.Millisecond
 )
 
+// teams keeps the teams the local players are assigned to, one by one, on the
+// team deathmatch
+var teams = []game.Team{game.TeamRed, game.TeamBlue}
+
+// Settings keeps the preferences the user can change from the menu
+type Settings struct {
+	// PlayerNames keeps the name for each one of the local players
+	PlayerNames []string
+	// Players is how many local players are sharing the keyboard
+	Players int
+	// Rules keeps the game mode, the friendly fire, the frag limit and the fog
+	// of war
+	Rules game.Rules
+	// Difficulty keeps how hard are the bots
+	Difficulty game.Difficulty
+	// Input keeps how the actions are dropped when the engine can't keep up
+	// with the keyboard
+	Input game.InputPolicy
+}
+
 // UserInterface will keep the basics for render the game on a terminal and listen
 // for all the interacionts from the user
 type UserInterface struct {
@@ -24,48 +46,111 @@ type UserInterface struct {
 	viewPort      *tview.Box
 	drawCallbacks []func()
 	MainPlayerID  uuid.UUID
+	// Players keeps the local players sharing the keyboard, the first one is
+	// the main player
+	Players []Player
+	// Levels keeps all the levels that can be played from the menu
+	Levels []game.Level
+	// Settings keeps the preferences used when a new game starts
+	Settings Settings
+	// level is the index of the level is playing
+	level int
+	// unlockedLevel is the index of the last level the player can select
+	unlockedLevel int
+	// Leaderboard keeps the high scores, when it is nil the scores are not
+	// recorded
+	Leaderboard *game.Leaderboard
+	// StatsOut is the file where the stats of each match are exported as
+	// JSON, when it is empty the stats are not exported
+	StatsOut string
+	// levelList is the list shown on the level select screen
+	levelList *tview.List
+	// leaderboardView is the text shown on the leaderboard screen
+	leaderboardView *tview.TextView
+	// debug keeps the state of the debug overlay
+	debug debugOverlay
+	// editor keeps the map is being edited on the level editor
+	editor levelEditor
+	// playtesting is the flag that determines when the game is playing the
+	// map of the level editor
+	playtesting bool
+	// spectating is the flag that determines when the local players are on
+	// autopilot and the user is only watching the match
+	spectating bool
+	// spectator keeps the camera used while spectating
+	spectator spectator
+	// shared is the flag that determines when the engine is shared with other
+	// user interfaces, see Join
+	shared bool
+	// joining keeps the engine given on the last call to Join until it is
+	// attached
+	joining   *sharedEngine
+	joinMutex sync.Mutex
+	// roundOver is the flag that determines when the end of round modal is shown
+	roundOver bool
+	// fog keeps what the local players see when the fog of war is on
+	fog fogOfWar
 }
 
-// New function will build a new View with the basics intialized
-func New(engine *game.Engine) *UserInterface {
+// New function will build a new View with the basics intialized, the user
+// interface starts on the main menu with the given levels ready to be played
+func New(levels ...game.Level) *UserInterface {
 	app := tview.NewApplication()
 	pages := tview.NewPages()
 	ui := &UserInterface{
-		Engine:  engine,
 		App:     app,
 		pages:   pages,
 		ErrChan: make(chan error),
+		Levels:  levels,
+		Settings: Settings{
+			PlayerNames: []string{"Player 1", "Player 2"},
+			Players:     1,
+			Rules:       game.Rules{FragLimit: game.DefaultFragLimit},
+			Input:       game.DefaultInputPolicy,
+		},
 	}
 	ui.drawViewPort()
 	ui.draw(
-		ui.drawLasers(),
-		ui.drawBots(),
-		ui.drawActors(),
 		ui.drawMap(),
+		ui.drawLasers(),
+		ui.drawEntities(),
+		ui.drawHUD(),
+		ui.drawBossHealth(),
+		ui.drawSpectator(),
+		ui.drawDebug(),
 	)
 	ui.setupDrawCallbacks(
 		ui.setupScore(),
 		ui.setupLevelComplete(),
 		ui.setupGameOver(),
+		ui.setupSharedRoundOver(),
 	)
+	ui.setupMenu()
 	ui.setupListeners()
 	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Rune() == 'p' {
+		if event.Key() == tcell.KeyCtrlC {
+			ui.quit()
+			return event
+		}
+		if page, _ := pages.GetFrontPage(); page != "viewport" && page != "score" {
+			return event
+		}
+		switch event.Rune() {
+		case 'p':
 			pages.ShowPage("score")
+		case 'm':
+			ui.showMenu()
+		}
+		if event.Key() == tcell.KeyF3 {
+			ui.toggleDebug()
 		}
-		switch event.Key() {
-		case tcell.KeyEsc:
+		if event.Key() == tcell.KeyEsc {
 			pages.HidePage("score")
 			app.SetFocus(ui.viewPort)
-		case tcell.KeyCtrlC:
-			app.Stop()
-			select {
-			case ui.ErrChan <- nil:
-			default:
-			}
 		}
 		return event
 	})
+	ui.showMenu()
 	app.SetRoot(pages, true)
 	return ui
 }
@@ -76,9 +161,15 @@ func (ui *UserInterface) Start() {
 	stop := make(chan bool)
 	go func() {
 		for {
-			for _, callback := range ui.drawCallbacks {
-				ui.App.QueueUpdate(callback)
-			}
+			ui.App.QueueUpdate(func() {
+				ui.attachJoined()
+				if ui.Engine == nil {
+					return
+				}
+				for _, callback := range ui.drawCallbacks {
+					callback()
+				}
+			})
 			ui.App.Draw()
 			<-drawTicker.C
 			select {
@@ -102,6 +193,133 @@ func (ui *UserInterface) Start() {
 	}()
 }
 
+// quit will stop the running game and the user interface, a shared engine
+// keeps running for the rest of the players
+func (ui *UserInterface) quit() {
+	if ui.Engine != nil && !ui.shared {
+		ui.Engine.Stop()
+	}
+	ui.App.Stop()
+	select {
+	case ui.ErrChan <- nil:
+	default:
+	}
+}
+
+// startLevel will start a new game with the level on the given index
+func (ui *UserInterface) startLevel(level int) {
+	ui.level = level
+	ui.playtesting = false
+	ui.spectating = false
+	ui.play(ui.Levels[level])
+}
+
+// retry will start again the level is playing, or the map of the level editor
+// while playtesting, or the level is watching while spectating
+func (ui *UserInterface) retry() {
+	if ui.playtesting {
+		ui.playtest()
+		return
+	}
+	if ui.spectating {
+		ui.spectate(ui.level)
+		return
+	}
+	ui.startLevel(ui.level)
+}
+
+// play will stop the current game, if any, and will start a new engine with
+// the given level, when the level can't be played the error is shown instead
+func (ui *UserInterface) play(level game.Level) {
+	if ui.Engine != nil {
+		ui.Engine.Stop()
+	}
+	var entities []game.Entity
+	ui.Players = nil
+	ui.fog = fogOfWar{}
+	if err := ui.Settings.Validate(); err != nil {
+		ui.Engine = nil
+		ui.showError(fmt.Sprintf("Can't play %s", level.Name), err)
+		return
+	}
+	for i := 0; i < ui.Settings.Players; i++ {
+		player := DefaultPlayers[i]
+		entity := game.NewPlayer(ui.Settings.playerName(i), ui.Settings.Difficulty)
+		if ui.Settings.Rules.Mode == game.ModeTeamDeathmatch {
+			entity.Team = teams[i%len(teams)]
+		}
+		if ui.spectating {
+			entity.Controller = game.Controller{Autopilot: true, Strategy: game.ShootAndMoveStrategy}
+		}
+		entity.Renderable = game.Renderable{
+			Glyph: player.Glyph,
+			Color: fmt.Sprintf("#%06x", player.Color.Hex()),
+		}
+		// The local players start one next to the other from the center of
+		// the map when it has no player spawns
+		entity.Position = game.Point{X: i * 2, Y: 0}
+		entities = append(entities, entity)
+		player.EntityID = entity.ID
+		ui.Players = append(ui.Players, player)
+	}
+	engine, err := game.NewEngine(
+		game.SetRules(ui.Settings.Rules),
+		game.SetDifficulty(ui.Settings.Difficulty),
+		game.SetInputPolicy(ui.Settings.Input),
+		game.SetLevel(level),
+		game.SetPlayers(entities...),
+	)
+	if err != nil {
+		ui.Engine = nil
+		ui.showError(fmt.Sprintf("Can't play %s", level.Name), err)
+		return
+	}
+	engine.Start()
+	ui.Engine = engine
+	ui.MainPlayerID = ui.Players[0].EntityID
+	ui.roundOver = false
+	ui.showGame()
+}
+
+// Validate returns an error when the settings can't be played, there are key
+// bindings for up to as many local players as DefaultPlayers has
+func (s Settings) Validate() error {
+	if s.Players < 1 || s.Players > len(DefaultPlayers) {
+		return fmt.Errorf("The number of players should be between 1 and %d", len(DefaultPlayers))
+	}
+	return nil
+}
+
+// playerName returns the name chosen for the local player on the given index
+func (s Settings) playerName(index int) string {
+	if index < len(s.PlayerNames) && s.PlayerNames[index] != "" {
+		return s.PlayerNames[index]
+	}
+	return fmt.Sprintf("Player %d", index+1)
+}
+
+// showError will render a modal with the given error, once closed the menu
+// comes back, or the level editor while playtesting
+func (ui *UserInterface) showError(title string, err error) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s\n\n%v", title, err)).
+		AddButtons([]string{"Ok"}).
+		SetDoneFunc(func(int, string) {
+			ui.pages.RemovePage("error")
+			ui.showMenu()
+		})
+	ui.pages.RemovePage("error")
+	ui.pages.AddPage("error", modal, true, true)
+	ui.App.SetFocus(modal)
+}
+
+// showGame will bring the view port to the front and resume the game
+func (ui *UserInterface) showGame() {
+	ui.Engine.Resume()
+	ui.pages.SwitchToPage("viewport")
+	ui.App.SetFocus(ui.viewPort)
+}
+
 // drawViewPort will render the screen where it going to start the game
 func (ui *UserInterface) drawViewPort() {
 	box := tview.NewBox().
@@ -111,61 +329,41 @@ func (ui *UserInterface) drawViewPort() {
 
 	helpText := tview.NewTextView().
 		SetTextAlign(tview.AlignCenter).
-		SetText("← → ↑ ↓ move - wasd shoot - p score - esc close - ctrl+c quit").
+		SetText("← → ↑ ↓ / ijkl / 8456 / [;'/ move - wasd / tfgh / excv / ybnu shoot\np score - esc close - m menu - f3 debug - ctrl+c quit").
 		SetTextColor(textColor)
 	helpText.SetBackgroundColor(backgroundColor)
 	flex := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(box, 0, 1, true).
-		AddItem(helpText, 1, 1, false)
-	ui.pages.AddPage("viewport", flex, true, true)
+		AddItem(helpText, 2, 1, false)
+	ui.pages.AddPage("viewport", flex, true, false)
 	ui.viewPort = box
 }
 
 // setupListeners will take care of all the inputs we receive from the user
-// and apply the related actions
+// and apply the related actions for each one of the local players, while
+// spectating the keys move the camera instead
 func (ui *UserInterface) setupListeners() {
 	ui.viewPort.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		var direction game.Direction
-		switch event.Key() {
-		case tcell.KeyUp:
-			direction = game.DirectionUp
-		case tcell.KeyDown:
-			direction = game.DirectionDown
-		case tcell.KeyRight:
-			direction = game.DirectionRight
-		case tcell.KeyLeft:
-			direction = game.DirectionLeft
+		if ui.spectating {
+			ui.spectatorInput(event)
+			return event
 		}
-		if direction != game.DirectionNone {
-			ui.Engine.ActionChan <- &game.MoveAction{
-				ActorID:   ui.MainPlayerID,
-				Direction: direction,
-				CreatedAt: time.Now(),
+		key := keyFromEvent(event)
+		for _, player := range ui.Players {
+			if direction, exists := player.Keys.Move[key]; exists {
+				ui.Engine.SubmitMove(&game.MoveAction{
+					EntityID:  player.EntityID,
+					Direction: direction,
+					CreatedAt: time.Now(),
+				})
 			}
-		}
-		var laserDirection game.Direction
-		switch event.Rune() {
-		case 'w':
-			laserDirection = game.DirectionUp
-		case 'd':
-			laserDirection = game.DirectionRight
-		case 's':
-			laserDirection = game.DirectionDown
-		case 'a':
-			laserDirection = game.DirectionLeft
-		}
-		if laserDirection != game.DirectionNone {
-			laserID := uuid.Must(uuid.NewV4())
-			ui.Engine.Lasers.Store(laserID, game.Laser{
-				ID:       laserID,
-				Position: ui.Engine.Actors[ui.MainPlayerID].Position,
-				Origin:   game.OriginPlayer,
-			})
-			ui.Engine.ActionChan <- &game.LaserAction{
-				LaserID:   laserID,
-				Direction: laserDirection,
-				CreatedAt: time.Now(),
+			if laserDirection, exists := player.Keys.Shoot[key]; exists {
+				ui.Engine.Submit(&game.FireAction{
+					ShooterID: player.EntityID,
+					Direction: laserDirection,
+					CreatedAt: time.Now(),
+				})
 			}
 		}
 		return event